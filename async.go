@@ -0,0 +1,185 @@
+package warnings
+
+import (
+	"context"
+	"sync"
+)
+
+// OverflowPolicy defines what an [AsyncWriter] does when its queue is full.
+type OverflowPolicy int
+
+const (
+	// Block blocks the caller until there is room in the queue.
+	Block OverflowPolicy = iota
+	// DropNewest discards the warning being written and returns [ErrQueueFull].
+	DropNewest
+	// DropOldest discards the oldest queued warning to make room for the new one.
+	// The discarded warning is reported to the error handler with [ErrQueueFull].
+	DropOldest
+)
+
+// AsyncOptions configures an [AsyncWriter].
+type AsyncOptions struct {
+	// QueueSize is the maximum number of queued warnings. Zero means unbounded.
+	QueueSize int
+	// Overflow is the policy applied when the queue is full.
+	Overflow OverflowPolicy
+	// OnError is called when a warning could not be delivered to the underlying writer.
+	// It is called from the background goroutine, except for warnings dropped by [DropOldest],
+	// which are reported by the writing goroutine. It may use the async writer.
+	OnError func(wrr Warning, err error)
+}
+
+// AsyncWriter is a [Writer] that queues warnings and delivers them to an underlying writer
+// on a background goroutine, so that writing a warning does not block on a slow writer.
+// Warnings are delivered in the order they were written.
+// The async writer is thread-safe.
+type AsyncWriter struct {
+	w    Writer
+	opts AsyncOptions
+
+	mtx    sync.Mutex
+	cond   *sync.Cond
+	queue  []asyncItem
+	n      int // number of queued warnings, flush markers excluded
+	closed bool
+	done   chan struct{}
+}
+
+type asyncItem struct {
+	wrr     Warning
+	flushed chan struct{} // set for flush markers only
+}
+
+// NewAsyncWriter returns a new AsyncWriter that delivers warnings to w.
+// The background goroutine runs until the writer is closed.
+func NewAsyncWriter(w Writer, opts AsyncOptions) *AsyncWriter {
+	a := &AsyncWriter{w: w, opts: opts, done: make(chan struct{})}
+	a.cond = sync.NewCond(&a.mtx)
+	go a.run()
+	return a
+}
+
+// WriteWarning queues a warning for delivery.
+// It returns [ErrQueueFull] if the warning is dropped by the [DropNewest] policy
+// and [ErrClosed] if the writer is closed.
+// Delivery errors are reported to [AsyncOptions.OnError].
+func (a *AsyncWriter) WriteWarning(wrr Warning) error {
+	dropped, err := a.enqueue(wrr)
+	// report outside of the lock, so that the error handler can use the writer
+	for _, d := range dropped {
+		a.report(d, ErrQueueFull)
+	}
+	return err
+}
+
+// enqueue queues a warning and returns the warnings dropped by the [DropOldest] policy to make room for it.
+func (a *AsyncWriter) enqueue(wrr Warning) ([]Warning, error) {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+	var dropped []Warning
+	for !a.closed && a.opts.QueueSize > 0 && a.n >= a.opts.QueueSize {
+		switch a.opts.Overflow {
+		case DropNewest:
+			return dropped, ErrQueueFull
+		case DropOldest:
+			dropped = append(dropped, a.dropOldest())
+		default:
+			a.cond.Wait()
+		}
+	}
+	if a.closed {
+		return dropped, ErrClosed
+	}
+	a.queue = append(a.queue, asyncItem{wrr: wrr})
+	a.n++
+	a.cond.Broadcast()
+	return dropped, nil
+}
+
+// Flush waits until all the warnings written before the call are delivered
+// or the context is done, in which case it returns the context error.
+func (a *AsyncWriter) Flush(ctx context.Context) error {
+	a.mtx.Lock()
+	if a.closed {
+		a.mtx.Unlock()
+		return ErrClosed
+	}
+	flushed := make(chan struct{})
+	a.queue = append(a.queue, asyncItem{flushed: flushed})
+	a.cond.Broadcast()
+	a.mtx.Unlock()
+	select {
+	case <-flushed:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Close stops accepting new warnings and waits until the queued warnings are delivered
+// or the context is done, in which case it returns the context error and
+// the remaining warnings are still delivered in the background.
+func (a *AsyncWriter) Close(ctx context.Context) error {
+	a.mtx.Lock()
+	if a.closed {
+		a.mtx.Unlock()
+		return ErrClosed
+	}
+	a.closed = true
+	a.cond.Broadcast()
+	a.mtx.Unlock()
+	select {
+	case <-a.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// dropOldest removes and returns the oldest queued warning. The caller must hold the lock.
+func (a *AsyncWriter) dropOldest() Warning {
+	for i, item := range a.queue {
+		if item.flushed != nil {
+			continue
+		}
+		a.queue = append(a.queue[:i], a.queue[i+1:]...)
+		a.n--
+		return item.wrr
+	}
+	return nil
+}
+
+func (a *AsyncWriter) report(wrr Warning, err error) {
+	if a.opts.OnError != nil {
+		a.opts.OnError(wrr, err)
+	}
+}
+
+func (a *AsyncWriter) run() {
+	defer close(a.done)
+	for {
+		a.mtx.Lock()
+		for len(a.queue) == 0 && !a.closed {
+			a.cond.Wait()
+		}
+		if len(a.queue) == 0 {
+			a.mtx.Unlock()
+			return
+		}
+		item := a.queue[0]
+		a.queue = a.queue[1:]
+		if item.flushed == nil {
+			a.n--
+		}
+		a.cond.Broadcast()
+		a.mtx.Unlock()
+		if item.flushed != nil {
+			close(item.flushed)
+			continue
+		}
+		if err := a.w.WriteWarning(item.wrr); err != nil {
+			a.report(item.wrr, err)
+		}
+	}
+}
@@ -0,0 +1,201 @@
+package warnings_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/runbed/warnings"
+)
+
+// gatedWriter blocks every write until the gate is opened.
+type gatedWriter struct {
+	gate chan struct{}
+	mtx  sync.Mutex
+	buf  []warnings.Warning
+}
+
+func (w *gatedWriter) WriteWarning(wrr warnings.Warning) error {
+	<-w.gate
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+	w.buf = append(w.buf, wrr)
+	return nil
+}
+
+func (w *gatedWriter) warnings() []string {
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+	var result []string
+	for _, wrr := range w.buf {
+		result = append(result, wrr.Warn())
+	}
+	return result
+}
+
+func TestAsyncWriter(t *testing.T) {
+	c := warnings.NewCollector()
+	a := warnings.NewAsyncWriter(c, warnings.AsyncOptions{})
+	ctx := warnings.Attach(context.Background(), a)
+	warnings.Warnf(ctx, "test-1")
+	warnings.Warnf(ctx, "test-2")
+	if err := a.Flush(context.Background()); err != nil {
+		t.Fatalf("expected nil, got %v", err)
+	}
+	wrrs, err := warnings.ReadAll(c)
+	if err != nil {
+		t.Fatalf("expected nil, got %v", err)
+	}
+	if len(wrrs) != 2 || wrrs[0].Warn() != "test-1" || wrrs[1].Warn() != "test-2" {
+		t.Fatalf("expected [test-1 test-2], got %v", wrrs)
+	}
+	if err := a.Close(context.Background()); err != nil {
+		t.Fatalf("expected nil, got %v", err)
+	}
+	if err := a.WriteWarning(warnings.New("test-3")); err != warnings.ErrClosed {
+		t.Fatalf("expected %v, got %v", warnings.ErrClosed, err)
+	}
+	if err := a.Flush(context.Background()); err != warnings.ErrClosed {
+		t.Fatalf("expected %v, got %v", warnings.ErrClosed, err)
+	}
+	if err := a.Close(context.Background()); err != warnings.ErrClosed {
+		t.Fatalf("expected %v, got %v", warnings.ErrClosed, err)
+	}
+}
+
+func TestAsyncWriter_CloseDelivers(t *testing.T) {
+	w := &gatedWriter{gate: make(chan struct{})}
+	a := warnings.NewAsyncWriter(w, warnings.AsyncOptions{})
+	for i := 0; i < 3; i++ {
+		if err := a.WriteWarning(warnings.New(fmt.Sprintf("test-%d", i))); err != nil {
+			t.Fatalf("expected nil, got %v", err)
+		}
+	}
+	close(w.gate)
+	if err := a.Close(context.Background()); err != nil {
+		t.Fatalf("expected nil, got %v", err)
+	}
+	if got := w.warnings(); len(got) != 3 {
+		t.Fatalf("expected 3 warnings, got %v", got)
+	}
+}
+
+func TestAsyncWriter_FlushTimeout(t *testing.T) {
+	w := &gatedWriter{gate: make(chan struct{})}
+	a := warnings.NewAsyncWriter(w, warnings.AsyncOptions{})
+	defer a.Close(context.Background())
+	defer close(w.gate)
+	a.WriteWarning(warnings.New("test"))
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := a.Flush(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected %v, got %v", context.DeadlineExceeded, err)
+	}
+}
+
+func TestAsyncWriter_DropNewest(t *testing.T) {
+	w := &gatedWriter{gate: make(chan struct{})}
+	a := warnings.NewAsyncWriter(w, warnings.AsyncOptions{QueueSize: 1, Overflow: warnings.DropNewest})
+	// the first warning may be picked up by the background goroutine, so fill the queue
+	var err error
+	for i := 0; i < 3 && err == nil; i++ {
+		err = a.WriteWarning(warnings.New(fmt.Sprintf("test-%d", i)))
+	}
+	if err != warnings.ErrQueueFull {
+		t.Fatalf("expected %v, got %v", warnings.ErrQueueFull, err)
+	}
+	close(w.gate)
+	if err := a.Close(context.Background()); err != nil {
+		t.Fatalf("expected nil, got %v", err)
+	}
+}
+
+func TestAsyncWriter_DropOldest(t *testing.T) {
+	var (
+		mtx     sync.Mutex
+		dropped []warnings.Warning
+	)
+	w := &gatedWriter{gate: make(chan struct{})}
+	a := warnings.NewAsyncWriter(w, warnings.AsyncOptions{
+		QueueSize: 1,
+		Overflow:  warnings.DropOldest,
+		OnError: func(wrr warnings.Warning, err error) {
+			if err != warnings.ErrQueueFull {
+				t.Errorf("expected %v, got %v", warnings.ErrQueueFull, err)
+			}
+			mtx.Lock()
+			defer mtx.Unlock()
+			dropped = append(dropped, wrr)
+		},
+	})
+	for i := 0; i < 5; i++ {
+		if err := a.WriteWarning(warnings.New(fmt.Sprintf("test-%d", i))); err != nil {
+			t.Fatalf("expected nil, got %v", err)
+		}
+	}
+	close(w.gate)
+	if err := a.Close(context.Background()); err != nil {
+		t.Fatalf("expected nil, got %v", err)
+	}
+	got := w.warnings()
+	if len(got) == 0 || got[len(got)-1] != "test-4" {
+		t.Fatalf("expected the newest warning to be delivered, got %v", got)
+	}
+	if len(got)+len(dropped) != 5 {
+		t.Fatalf("expected 5 delivered or dropped warnings, got %v and %v", got, dropped)
+	}
+}
+
+func TestAsyncWriter_OnError(t *testing.T) {
+	wantErr := fmt.Errorf("test-error")
+	errs := make(chan error, 1)
+	a := warnings.NewAsyncWriter(&mockWriter{result: wantErr}, warnings.AsyncOptions{
+		OnError: func(wrr warnings.Warning, err error) {
+			errs <- err
+		},
+	})
+	if err := a.WriteWarning(warnings.New("test")); err != nil {
+		t.Fatalf("expected nil, got %v", err)
+	}
+	if err := a.Close(context.Background()); err != nil {
+		t.Fatalf("expected nil, got %v", err)
+	}
+	if err := <-errs; err != wantErr {
+		t.Fatalf("expected %v, got %v", wantErr, err)
+	}
+}
+
+func TestAsyncWriter_ReentrantOnError(t *testing.T) {
+	w := &gatedWriter{gate: make(chan struct{})}
+	var a *warnings.AsyncWriter
+	a = warnings.NewAsyncWriter(w, warnings.AsyncOptions{
+		QueueSize: 1,
+		Overflow:  warnings.DropOldest,
+		OnError: func(wrr warnings.Warning, err error) {
+			// log the dropped warning to the same writer
+			if !strings.HasPrefix(wrr.Warn(), "dropped") {
+				a.WriteWarning(warnings.New("dropped " + wrr.Warn()))
+			}
+		},
+	})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 3; i++ {
+			a.WriteWarning(warnings.New(fmt.Sprintf("test-%d", i)))
+		}
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatalf("expected the writes to complete, the error handler deadlocked")
+	}
+	close(w.gate)
+	if err := a.Close(context.Background()); err != nil {
+		t.Fatalf("expected nil, got %v", err)
+	}
+}
@@ -0,0 +1,80 @@
+package warnings
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+)
+
+// WithAttrs returns a new context whose warnings are enriched with the given attributes.
+// Attributes accumulate as contexts nest, in nesting order. An attribute with the same key
+// as an attribute of the parent context replaces it.
+// Use [AttrsOf] to read the attributes of a warning.
+func WithAttrs(ctx context.Context, attrs ...slog.Attr) context.Context {
+	if len(attrs) == 0 {
+		return ctx
+	}
+	p := *getPipeline(ctx)
+	p.attrs = mergeAttrs(p.attrs, attrs)
+	return setPipeline(ctx, p)
+}
+
+// mergeAttrs returns a new slice with the attributes of b appended to a,
+// replacing the attributes of a with the same key.
+func mergeAttrs(a, b []slog.Attr) []slog.Attr {
+	result := slices.Clone(a)
+	for _, attr := range b {
+		i := slices.IndexFunc(result, func(found slog.Attr) bool {
+			return found.Key == attr.Key
+		})
+		if i < 0 {
+			result = append(result, attr)
+		} else {
+			result[i] = attr
+		}
+	}
+	return result
+}
+
+// AttrsOf returns the attributes of the warning.
+// Warnings can carry their own attributes by implementing an Attrs() []slog.Attr method.
+// Attributes of wrapped warnings come first and are replaced by attributes of
+// the wrapping warnings with the same key.
+func AttrsOf(wrr Warning) []slog.Attr {
+	var chain [][]slog.Attr
+	for ; wrr != nil; wrr = Unwrap(wrr) {
+		if a, ok := wrr.(interface{ Attrs() []slog.Attr }); ok {
+			chain = append(chain, a.Attrs())
+		}
+	}
+	var result []slog.Attr
+	for i := len(chain) - 1; i >= 0; i-- {
+		result = mergeAttrs(result, chain[i])
+	}
+	return result
+}
+
+type attrsWarning struct {
+	Warning
+	attrs []slog.Attr
+}
+
+// withAttrs enriches the warning with the attributes unless there are none.
+func withAttrs(wrr Warning, attrs []slog.Attr) Warning {
+	if wrr == nil || len(attrs) == 0 {
+		return wrr
+	}
+	return &attrsWarning{wrr, attrs}
+}
+
+func (wrr *attrsWarning) Attrs() []slog.Attr {
+	return slices.Clone(wrr.attrs)
+}
+
+func (wrr *attrsWarning) Unwrap() Warning {
+	return wrr.Warning
+}
+
+func (wrr *attrsWarning) MarshalJSON() ([]byte, error) {
+	return marshalJSON(wrr)
+}
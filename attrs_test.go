@@ -0,0 +1,89 @@
+package warnings_test
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"testing"
+
+	"github.com/runbed/warnings"
+)
+
+// ExampleWithAttrs demonstrates how to use the WithAttrs function to enrich warnings with attributes.
+func ExampleWithAttrs() {
+	// create a new collector
+	collector := warnings.NewCollector()
+	defer collector.Close() // make sure to close the collector when done
+	// attach the collector to a context
+	ctx := warnings.Attach(context.Background(), collector)
+	// use WithAttrs to enrich every warning written to the context
+	ctx = warnings.WithAttrs(ctx, slog.String("request_id", "42"))
+	ctx = warnings.WithAttrs(ctx, slog.String("job", "import"))
+	warnings.Warnf(ctx, "this is a warning")
+	// read all warnings from the collector
+	wrrs, err := warnings.ReadAll(collector)
+	if err != nil {
+		// handle error
+	}
+	for _, wrr := range wrrs {
+		fmt.Println(wrr.Warn(), warnings.AttrsOf(wrr))
+	}
+	// Output:
+	// this is a warning [request_id=42 job=import]
+}
+
+func TestWithAttrs(t *testing.T) {
+	w := &mockWriter{}
+	ctx := warnings.Attach(context.Background(), w)
+	ctx = warnings.WithAttrs(ctx, slog.String("a", "1"), slog.Int("b", 2))
+	ctx = warnings.WithAttrs(ctx, slog.String("a", "3"), slog.Bool("c", true))
+	warnings.Warn(ctx, warnings.New("test"))
+	if len(w.buf) != 1 {
+		t.Fatalf("expected 1 warning, got %v", len(w.buf))
+	}
+	got := warnings.AttrsOf(w.buf[0])
+	want := []slog.Attr{slog.String("a", "3"), slog.Int("b", 2), slog.Bool("c", true)}
+	if len(got) != len(want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	for i := range want {
+		if !got[i].Equal(want[i]) {
+			t.Fatalf("expected %v, got %v", want, got)
+		}
+	}
+}
+
+type attrsWarn struct {
+	attrs []slog.Attr
+}
+
+func (w *attrsWarn) Warn() string {
+	return "attrs"
+}
+
+func (w *attrsWarn) Attrs() []slog.Attr {
+	return w.attrs
+}
+
+func TestWithAttrs_Merge(t *testing.T) {
+	w := &mockWriter{}
+	ctx := warnings.Attach(context.Background(), w)
+	ctx = warnings.WithAttrs(ctx, slog.String("a", "ctx"))
+	warnings.Warn(ctx, &attrsWarn{[]slog.Attr{slog.String("a", "own"), slog.String("b", "own")}})
+	if len(w.buf) != 1 {
+		t.Fatalf("expected 1 warning, got %v", len(w.buf))
+	}
+	if got, want := fmt.Sprint(warnings.AttrsOf(w.buf[0])), "[a=ctx b=own]"; got != want {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+}
+
+func TestWithAttrs_Empty(t *testing.T) {
+	ctx := context.Background()
+	if got := warnings.WithAttrs(ctx); got != ctx {
+		t.Fatalf("expected the same context")
+	}
+	if got := warnings.AttrsOf(warnings.New("test")); len(got) != 0 {
+		t.Fatalf("expected no attributes, got %v", got)
+	}
+}
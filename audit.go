@@ -0,0 +1,86 @@
+package warnings
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+)
+
+// Decision is the decision of a layer on a warning, recorded by [Audit].
+type Decision string
+
+const (
+	// Dropped means the layer did not pass the warning on.
+	Dropped Decision = "dropped"
+	// Changed means the layer passed another warning on.
+	// Warnings that are only annotated, i.e. wrapped with metadata, are not reported as changed.
+	Changed Decision = "changed"
+	// Failed means the layer returned an error for the warning, e.g. with [Strict].
+	Failed Decision = "failed"
+)
+
+// AuditEvent records the decision of a layer on a warning. It is itself a [Warning].
+type AuditEvent struct {
+	// Layer is the layer that made the decision.
+	Layer *Layer
+	// Decision is what the layer did with the warning.
+	Decision Decision
+	// Warning is the warning received by the layer.
+	Warning Warning
+	// Result is the warning passed on by the layer, or nil if it was dropped.
+	Result Warning
+	// Err is the error returned by the layer.
+	Err error
+}
+
+// Warn returns a description of the event.
+func (e *AuditEvent) Warn() string {
+	layer := e.Layer.Kind
+	if e.Layer.Name != "" {
+		layer += fmt.Sprintf(" %q", e.Layer.Name)
+	}
+	switch e.Decision {
+	case Changed:
+		return fmt.Sprintf("%s changed %q to %q", layer, e.Warning.Warn(), e.Result.Warn())
+	case Failed:
+		return fmt.Sprintf("%s failed %q: %v", layer, e.Warning.Warn(), e.Err)
+	}
+	return fmt.Sprintf("%s dropped %q", layer, e.Warning.Warn())
+}
+
+// Audit returns a new context in which the layers record their decisions on each warning to w,
+// as [AuditEvent] warnings: which layer dropped a warning, what a layer changed it from and to,
+// and which layer returned an error for it. It can be used, e.g. in staging, to find out which
+// warnings a production configuration would hide.
+// Only the layers created from the returned context, e.g. with [Filter], [Map], [Detach], [Strict]
+// or [WithPolicy], are audited, so audit should be enabled before the layers are configured.
+func Audit(ctx context.Context, w Writer) context.Context {
+	p := *getPipeline(ctx)
+	p.audit = w
+	return setPipeline(ctx, p)
+}
+
+// audit records the decision of a layer, if any. Errors writing to the audit writer are ignored.
+func audit(w Writer, l *Layer, wrr, next Warning, err error) {
+	e := &AuditEvent{Layer: l, Warning: wrr, Result: next, Err: err}
+	switch {
+	case err != nil:
+		e.Decision = Failed
+	case next == nil:
+		e.Decision = Dropped
+	case !same(next, wrr) && !same(Unwrap(next), wrr):
+		e.Decision = Changed
+	default:
+		return
+	}
+	_ = w.WriteWarning(e)
+}
+
+// same reports whether a and b are the same warning, without panicking on non-comparable warnings.
+func same(a, b Warning) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	t := reflect.TypeOf(a)
+	return t == reflect.TypeOf(b) && t.Comparable() && a == b
+}
@@ -0,0 +1,133 @@
+package warnings_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/runbed/warnings"
+)
+
+// ExampleAudit demonstrates how to use the Audit function to record why warnings are dropped or changed.
+func ExampleAudit() {
+	// create a new collector for the warnings and one for the audit events
+	collector, audit := warnings.NewCollector(), warnings.NewCollector()
+	defer collector.Close() // make sure to close the collectors when done
+	defer audit.Close()
+	// enable audit before configuring the layers
+	ctx := warnings.Audit(context.Background(), audit)
+	ctx = warnings.Attach(ctx, collector)
+	ctx = warnings.Map(ctx, func(wrr warnings.Warning) warnings.Warning {
+		return warnings.New(strings.ToUpper(wrr.Warn()))
+	})
+	ctx = warnings.Filter(ctx, func(wrr warnings.Warning) bool {
+		return !strings.HasPrefix(wrr.Warn(), "ignore")
+	}, warnings.Named("ignore"))
+	// use Warn or Warnf to write warnings to the context
+	warnings.Warnf(ctx, "this is a warning")
+	warnings.Warnf(ctx, "ignore this warning")
+	// read all audit events
+	events, err := warnings.ReadAll(audit)
+	if err != nil {
+		// handle error
+	}
+	for _, e := range events {
+		fmt.Println(e.Warn())
+	}
+	// Output:
+	// map changed "this is a warning" to "THIS IS A WARNING"
+	// filter "ignore" dropped "ignore this warning"
+}
+
+func TestAudit(t *testing.T) {
+	audit := &mockWriter{}
+	ctx := warnings.Audit(context.Background(), audit)
+	ctx = warnings.Attach(ctx, &mockWriter{})
+	ctx = warnings.Strict(ctx, warnings.StrictPolicy{Selector: warnings.Selector{Codes: []string{"W1"}}})
+	ctx = warnings.WithAttrs(ctx)
+	ctx = warnings.Stamp(ctx, nil)
+	ctx = warnings.Tap(ctx, func(wrr warnings.Warning) {})
+	warnings.Warnf(ctx, "test-1")
+	err := warnings.Warn(ctx, warnings.WithCode(warnings.New("test-2"), "W1"))
+	if !errors.Is(err, warnings.ErrStrict) {
+		t.Fatalf("expected %v, got %v", warnings.ErrStrict, err)
+	}
+	if len(audit.buf) != 1 {
+		t.Fatalf("expected 1 audit event, got %v", audit.buf)
+	}
+	e, ok := audit.buf[0].(*warnings.AuditEvent)
+	if !ok {
+		t.Fatalf("expected an audit event, got %T", audit.buf[0])
+	}
+	if e.Decision != warnings.Failed || e.Layer.Kind != warnings.KindStrict || !errors.Is(e.Err, warnings.ErrStrict) {
+		t.Fatalf("expected a failed strict event, got %+v", e)
+	}
+	if got, want := e.Warn(), `strict failed "test-2": warning treated as error: test-2`; got != want {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+}
+
+func TestAudit_Detach(t *testing.T) {
+	audit := &mockWriter{}
+	ctx := warnings.Attach(context.Background(), &mockWriter{})
+	ctx = warnings.Audit(ctx, audit)
+	ctx = warnings.Detach(ctx, warnings.Named("quiet"))
+	warnings.Warnf(ctx, "test")
+	if len(audit.buf) != 1 {
+		t.Fatalf("expected 1 audit event, got %v", audit.buf)
+	}
+	if got, want := audit.buf[0].Warn(), `detach "quiet" dropped "test"`; got != want {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	if !warnings.Enabled(ctx) {
+		t.Fatalf("expected enabled when the detached warnings are audited")
+	}
+}
+
+func TestAudit_NoWriter(t *testing.T) {
+	audit := &mockWriter{}
+	ctx := warnings.Audit(context.Background(), audit)
+	if warnings.Enabled(ctx) {
+		t.Fatalf("expected disabled without writer")
+	}
+	called := false
+	warnings.WarnFunc(ctx, func() warnings.Warning {
+		called = true
+		return warnings.New("test")
+	})
+	if called || len(audit.buf) != 0 {
+		t.Fatalf("expected fn not to be called and no audit events, got %v", audit.buf)
+	}
+}
+
+func TestAudit_NotEnabled(t *testing.T) {
+	audit := &mockWriter{}
+	ctx := warnings.Attach(context.Background(), &mockWriter{})
+	ctx = warnings.Filter(ctx, func(wrr warnings.Warning) bool { return false })
+	ctx = warnings.Audit(ctx, audit)
+	warnings.Warnf(ctx, "test")
+	if len(audit.buf) != 0 {
+		t.Fatalf("expected layers created before Audit not to be audited, got %v", audit.buf)
+	}
+}
+
+type sliceWarn []string
+
+func (w sliceWarn) Warn() string {
+	return strings.Join(w, ", ")
+}
+
+func TestAudit_NonComparable(t *testing.T) {
+	audit := &mockWriter{}
+	ctx := warnings.Audit(context.Background(), audit)
+	ctx = warnings.Attach(ctx, &mockWriter{})
+	ctx = warnings.Map(ctx, func(wrr warnings.Warning) warnings.Warning {
+		return sliceWarn{"a", "b"}
+	})
+	warnings.Warn(ctx, sliceWarn{"a"})
+	if len(audit.buf) != 1 || audit.buf[0].Warn() != `map changed "a" to "a, b"` {
+		t.Fatalf("expected a change event, got %v", audit.buf)
+	}
+}
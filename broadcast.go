@@ -0,0 +1,154 @@
+package warnings
+
+import (
+	"io"
+	"sync"
+)
+
+// BroadcastOptions configures a [Broadcaster].
+type BroadcastOptions struct {
+	// History is the number of most recent warnings retained for late subscribers. Zero means none.
+	History int
+	// MaxLag is the maximum number of unread warnings of a subscriber. Zero means unbounded.
+	// A subscriber lagging further behind skips the oldest warnings, see [Subscriber.Dropped].
+	MaxLag int
+}
+
+// Broadcaster is a [Writer] that delivers every warning to all its subscribers,
+// e.g. a logger, a metrics counter and a UI reading the same stream.
+// Unlike a [Collector], which hands each warning to exactly one reader,
+// each [Subscriber] reads the stream with an independent cursor.
+// It implements the [Writer] and [io.Closer] interfaces.
+// The broadcaster is thread-safe.
+type Broadcaster struct {
+	opts   BroadcastOptions
+	mtx    sync.Mutex
+	buf    []Warning // retained warnings, from the sequence number start
+	start  uint64
+	subs   map[*Subscriber]struct{}
+	closed bool
+}
+
+// NewBroadcaster returns a new Broadcaster.
+func NewBroadcaster(opts BroadcastOptions) *Broadcaster {
+	return &Broadcaster{opts: opts, subs: make(map[*Subscriber]struct{})}
+}
+
+// end returns the sequence number of the next written warning. The caller must hold the lock.
+func (b *Broadcaster) end() uint64 {
+	return b.start + uint64(len(b.buf))
+}
+
+// WriteWarning writes a warning to all the subscribers.
+func (b *Broadcaster) WriteWarning(wrr Warning) error {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	if b.closed {
+		return ErrClosed
+	}
+	b.buf = append(b.buf, wrr)
+	if b.opts.MaxLag > 0 {
+		for s := range b.subs {
+			if lag := b.end() - s.pos; lag > uint64(b.opts.MaxLag) {
+				skipped := lag - uint64(b.opts.MaxLag)
+				s.pos += skipped
+				s.dropped += int(skipped)
+			}
+		}
+	}
+	b.trim()
+	return nil
+}
+
+// trim discards the warnings that are neither unread by a subscriber nor part of the history.
+// The caller must hold the lock.
+func (b *Broadcaster) trim() {
+	keep := b.historyStart()
+	for s := range b.subs {
+		keep = min(keep, s.pos)
+	}
+	b.buf = b.buf[keep-b.start:]
+	b.start = keep
+}
+
+// historyStart returns the sequence number of the oldest warning of the history. The caller must hold the lock.
+func (b *Broadcaster) historyStart() uint64 {
+	if uint64(len(b.buf)) <= uint64(b.opts.History) {
+		return b.start
+	}
+	return b.end() - uint64(b.opts.History)
+}
+
+// Subscribe returns a new subscriber that reads the retained history, then the warnings written afterwards.
+// If the broadcaster is closed, the subscriber is closed too.
+func (b *Broadcaster) Subscribe() *Subscriber {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	s := &Subscriber{b: b, pos: b.historyStart(), closed: b.closed}
+	if !b.closed {
+		b.subs[s] = struct{}{}
+	}
+	return s
+}
+
+// Close closes the broadcaster and all its subscribers.
+func (b *Broadcaster) Close() error {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	if b.closed {
+		return ErrClosed
+	}
+	b.closed = true
+	b.buf = nil
+	for s := range b.subs {
+		s.closed = true
+	}
+	b.subs = nil
+	return nil
+}
+
+// Subscriber reads the warnings of a [Broadcaster] with its own cursor.
+// It implements the [Reader] and [io.Closer] interfaces.
+// Read operations are non-blocking and return [io.EOF] when the subscriber has read all the written warnings.
+type Subscriber struct {
+	b       *Broadcaster
+	pos     uint64 // sequence number of the next warning to read
+	dropped int
+	closed  bool
+}
+
+// ReadWarning reads the next warning of the subscriber.
+func (s *Subscriber) ReadWarning() (Warning, error) {
+	s.b.mtx.Lock()
+	defer s.b.mtx.Unlock()
+	if s.closed {
+		return nil, ErrClosed
+	}
+	if s.pos == s.b.end() {
+		return nil, io.EOF
+	}
+	wrr := s.b.buf[s.pos-s.b.start]
+	s.pos++
+	s.b.trim()
+	return wrr, nil
+}
+
+// Dropped returns the number of warnings the subscriber skipped because it lagged behind, see [BroadcastOptions.MaxLag].
+func (s *Subscriber) Dropped() int {
+	s.b.mtx.Lock()
+	defer s.b.mtx.Unlock()
+	return s.dropped
+}
+
+// Close unsubscribes the subscriber from the broadcaster.
+func (s *Subscriber) Close() error {
+	s.b.mtx.Lock()
+	defer s.b.mtx.Unlock()
+	if s.closed {
+		return ErrClosed
+	}
+	s.closed = true
+	delete(s.b.subs, s)
+	s.b.trim()
+	return nil
+}
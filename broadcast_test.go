@@ -0,0 +1,116 @@
+package warnings_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/runbed/warnings"
+)
+
+// ExampleBroadcaster demonstrates how to read the same warnings from several subscribers.
+func ExampleBroadcaster() {
+	// create a new broadcaster
+	b := warnings.NewBroadcaster(warnings.BroadcastOptions{})
+	defer b.Close() // make sure to close the broadcaster when done
+	// subscribe before writing warnings
+	logger, counter := b.Subscribe(), b.Subscribe()
+	// attach the broadcaster to a context
+	ctx := warnings.Attach(context.Background(), b)
+	warnings.Warnf(ctx, "this is a warning")
+	warnings.Warnf(ctx, "this is another warning")
+	// each subscriber reads all the warnings
+	wrrs, err := warnings.ReadAll(logger)
+	if err != nil {
+		// handle error
+	}
+	for _, wrr := range wrrs {
+		fmt.Println(wrr.Warn())
+	}
+	wrrs, err = warnings.ReadAll(counter)
+	if err != nil {
+		// handle error
+	}
+	fmt.Println(len(wrrs))
+	// Output:
+	// this is a warning
+	// this is another warning
+	// 2
+}
+
+func TestBroadcaster(t *testing.T) {
+	b := warnings.NewBroadcaster(warnings.BroadcastOptions{})
+	s1 := b.Subscribe()
+	b.WriteWarning(warnings.New("a"))
+	s2 := b.Subscribe()
+	b.WriteWarning(warnings.New("b"))
+	if got, want := readAllStrings(t, s1), "[a b]"; got != want {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	b.WriteWarning(warnings.New("c"))
+	if got, want := readAllStrings(t, s2), "[b c]"; got != want {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	if got, want := readAllStrings(t, s1), "[c]"; got != want {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	if err := s1.Close(); err != nil {
+		t.Fatalf("expected nil, got %v", err)
+	}
+	if _, err := s1.ReadWarning(); err != warnings.ErrClosed {
+		t.Fatalf("expected %v, got %v", warnings.ErrClosed, err)
+	}
+	if err := b.Close(); err != nil {
+		t.Fatalf("expected nil, got %v", err)
+	}
+	if _, err := s2.ReadWarning(); err != warnings.ErrClosed {
+		t.Fatalf("expected %v, got %v", warnings.ErrClosed, err)
+	}
+	if err := b.WriteWarning(warnings.New("d")); err != warnings.ErrClosed {
+		t.Fatalf("expected %v, got %v", warnings.ErrClosed, err)
+	}
+	if _, err := b.Subscribe().ReadWarning(); err != warnings.ErrClosed {
+		t.Fatalf("expected %v, got %v", warnings.ErrClosed, err)
+	}
+}
+
+func TestBroadcaster_History(t *testing.T) {
+	b := warnings.NewBroadcaster(warnings.BroadcastOptions{History: 2})
+	s1 := b.Subscribe()
+	for _, msg := range []string{"a", "b", "c", "d"} {
+		b.WriteWarning(warnings.New(msg))
+	}
+	if got, want := readAllStrings(t, b.Subscribe()), "[c d]"; got != want {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	if got, want := readAllStrings(t, s1), "[a b c d]"; got != want {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	if got, want := readAllStrings(t, b.Subscribe()), "[c d]"; got != want {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+}
+
+func TestBroadcaster_MaxLag(t *testing.T) {
+	b := warnings.NewBroadcaster(warnings.BroadcastOptions{MaxLag: 2})
+	slow, fast := b.Subscribe(), b.Subscribe()
+	for _, msg := range []string{"a", "b", "c", "d", "e"} {
+		b.WriteWarning(warnings.New(msg))
+		if _, err := fast.ReadWarning(); err != nil {
+			t.Fatalf("expected nil, got %v", err)
+		}
+	}
+	if got, want := readAllStrings(t, slow), "[d e]"; got != want {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	if got := slow.Dropped(); got != 3 {
+		t.Fatalf("expected 3, got %v", got)
+	}
+	if got := fast.Dropped(); got != 0 {
+		t.Fatalf("expected 0, got %v", got)
+	}
+	if _, err := fast.ReadWarning(); err != io.EOF {
+		t.Fatalf("expected %v, got %v", io.EOF, err)
+	}
+}
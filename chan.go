@@ -0,0 +1,25 @@
+package warnings
+
+import "io"
+
+// ChanWriter returns a writer that sends warnings to the channel.
+// Writes block until the channel accepts the warning.
+// As with any channel send, writing after the channel is closed panics.
+func ChanWriter(ch chan<- Warning) Writer {
+	return WriterFunc(func(wrr Warning) error {
+		ch <- wrr
+		return nil
+	})
+}
+
+// FromChan returns a reader that receives warnings from the channel.
+// Reads block until a warning is received, and return [io.EOF] once the channel is closed and drained.
+func FromChan(ch <-chan Warning) Reader {
+	return ReaderFunc(func() (Warning, error) {
+		wrr, ok := <-ch
+		if !ok {
+			return nil, io.EOF
+		}
+		return wrr, nil
+	})
+}
@@ -0,0 +1,72 @@
+package warnings_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/runbed/warnings"
+)
+
+// ExampleChanWriter demonstrates how to receive warnings on a channel.
+func ExampleChanWriter() {
+	ch := make(chan warnings.Warning, 10)
+	// attach the channel to a context
+	ctx := warnings.Attach(context.Background(), warnings.ChanWriter(ch))
+	warnings.Warnf(ctx, "this is a warning")
+	close(ch)
+	for wrr := range ch {
+		fmt.Println(wrr.Warn())
+	}
+	// Output:
+	// this is a warning
+}
+
+func TestFromChan(t *testing.T) {
+	ch := make(chan warnings.Warning, 2)
+	ch <- warnings.New("a")
+	ch <- warnings.New("b")
+	close(ch)
+	if got, want := readAllStrings(t, warnings.FromChan(ch)), "[a b]"; got != want {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+}
+
+func TestCollector_Notify(t *testing.T) {
+	c := warnings.NewCollector()
+	notify := c.Notify()
+	select {
+	case <-notify:
+		t.Fatalf("expected no signal for an empty collector")
+	default:
+	}
+	c.WriteWarning(warnings.New("a"))
+	c.WriteWarning(warnings.New("b"))
+	select {
+	case <-notify:
+	default:
+		t.Fatalf("expected a signal after a write")
+	}
+	if got, want := readAllStrings(t, c), "[a b]"; got != want {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	c.Close()
+	if _, ok := <-notify; ok {
+		t.Fatalf("expected the channel to be closed")
+	}
+}
+
+func TestCollector_NotifyPrimed(t *testing.T) {
+	c := collectorOf("a")
+	select {
+	case <-c.Notify():
+	default:
+		t.Fatalf("expected a signal for a non-empty collector")
+	}
+	c.Close()
+	c = warnings.NewCollector()
+	c.Close()
+	if _, ok := <-c.Notify(); ok {
+		t.Fatalf("expected the channel of a closed collector to be closed")
+	}
+}
@@ -13,6 +13,7 @@ type Collector struct {
 	buf    []Warning
 	mtx    sync.Mutex
 	closed bool
+	notify chan struct{} // created by Notify
 }
 
 // NewCollector returns a new Collector.
@@ -27,8 +28,7 @@ func (c *Collector) Close() error {
 	if c.closed {
 		return ErrClosed
 	}
-	c.closed = true
-	c.buf = nil
+	c.close()
 	return nil
 }
 
@@ -40,9 +40,50 @@ func (c *Collector) WriteWarning(wrr Warning) error {
 		return ErrClosed
 	}
 	c.buf = append(c.buf, wrr)
+	c.signal()
 	return nil
 }
 
+// Notify returns a channel that receives a value when warnings are written to the collector,
+// so that a consumer can select on warnings alongside other events instead of polling [Collector.ReadWarning].
+// The channel has a buffer of one: several writes may be coalesced into a single signal,
+// so the consumer should read until [io.EOF] after each signal.
+// It receives a value right away if the collector is not empty, and it is closed when the collector is closed.
+// All the calls return the same channel.
+//
+//	for {
+//		select {
+//		case _, ok := <-collector.Notify():
+//			// read until io.EOF, stop if !ok
+//		case <-ctx.Done():
+//			return
+//		}
+//	}
+func (c *Collector) Notify() <-chan struct{} {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	if c.notify == nil {
+		c.notify = make(chan struct{}, 1)
+		if c.closed {
+			close(c.notify)
+		} else if len(c.buf) > 0 {
+			c.signal()
+		}
+	}
+	return c.notify
+}
+
+// signal notifies the channel returned by Notify, if any, without blocking. The caller must hold the lock.
+func (c *Collector) signal() {
+	if c.notify == nil {
+		return
+	}
+	select {
+	case c.notify <- struct{}{}:
+	default:
+	}
+}
+
 // ReadWarning reads a warning from the collector.
 func (c *Collector) ReadWarning() (Warning, error) {
 	c.mtx.Lock()
@@ -57,3 +98,24 @@ func (c *Collector) ReadWarning() (Warning, error) {
 	c.buf = c.buf[1:]
 	return w, nil
 }
+
+// drain atomically reads all the warnings and closes the collector.
+func (c *Collector) drain() ([]Warning, error) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	if c.closed {
+		return nil, ErrClosed
+	}
+	wrrs := c.buf
+	c.close()
+	return wrrs, nil
+}
+
+// close marks the collector as closed. The caller must hold the lock.
+func (c *Collector) close() {
+	c.closed = true
+	c.buf = nil
+	if c.notify != nil {
+		close(c.notify)
+	}
+}
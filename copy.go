@@ -0,0 +1,51 @@
+package warnings
+
+import (
+	"context"
+	"errors"
+	"io"
+)
+
+// Copy writes the warnings read from r to w until r returns [io.EOF], like [io.Copy].
+// It returns the number of warnings written and the first error encountered while reading or writing.
+func Copy(w Writer, r Reader) (int, error) {
+	n := 0
+	for {
+		wrr, err := r.ReadWarning()
+		if errors.Is(err, io.EOF) {
+			return n, nil
+		} else if err != nil {
+			return n, err
+		}
+		if err := w.WriteWarning(wrr); err != nil {
+			return n, err
+		}
+		n++
+	}
+}
+
+// Replay writes the warnings read from r to the context with [Warn] until r returns [io.EOF],
+// so that they go through the full pipeline of the context, including its filters, maps and policies.
+// For example, it can push the warnings recorded by a previous run, see [NewJSONReader],
+// or forward the warnings of a sub-task's collector to the parent context.
+// Replayed warnings keep their recorded scope and stamp.
+// It returns the number of warnings read. A read error stops the replay,
+// while the errors returned by [Warn] are joined and returned once all the warnings are replayed.
+func Replay(ctx context.Context, r Reader) (int, error) {
+	var errs []error
+	n := 0
+	for {
+		wrr, err := r.ReadWarning()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			errs = append(errs, err)
+			break
+		}
+		n++
+		if err := Warn(ctx, wrr); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return n, joinErrors(errs)
+}
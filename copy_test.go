@@ -0,0 +1,99 @@
+package warnings_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/runbed/warnings"
+)
+
+// ExampleReplay demonstrates how to push the warnings recorded by a previous run through the current pipeline.
+func ExampleReplay() {
+	// the warnings recorded by a previous run, see NewJSONWriter
+	recorded := strings.NewReader(`"this is a warning"
+{"warning":"this is ignored","code":"W1001"}
+`)
+	// create a new collector
+	collector := warnings.NewCollector()
+	defer collector.Close() // make sure to close the collector when done
+	// attach the collector to a context and filter the warnings
+	ctx := warnings.Attach(context.Background(), collector)
+	ctx = warnings.Filter(ctx, func(wrr warnings.Warning) bool {
+		return warnings.CodeOf(wrr) != "W1001"
+	})
+	// replay the recorded warnings through the context
+	n, err := warnings.Replay(ctx, warnings.NewJSONReader(recorded))
+	if err != nil {
+		// handle error
+	}
+	fmt.Println(n)
+	// read all warnings from the collector
+	wrrs, err := warnings.ReadAll(collector)
+	if err != nil {
+		// handle error
+	}
+	for _, wrr := range wrrs {
+		fmt.Println(wrr.Warn())
+	}
+	// Output:
+	// 2
+	// this is a warning
+}
+
+func TestCopy(t *testing.T) {
+	w := &mockWriter{}
+	n, err := warnings.Copy(w, collectorOf("a", "b"))
+	if err != nil {
+		t.Fatalf("expected nil, got %v", err)
+	}
+	if n != 2 || len(w.buf) != 2 {
+		t.Fatalf("expected 2 warnings, got %v and %v", n, w.buf)
+	}
+}
+
+func TestCopy_Errors(t *testing.T) {
+	wantErr := fmt.Errorf("test-error")
+	if n, err := warnings.Copy(&mockWriter{result: wantErr}, collectorOf("a", "b")); n != 0 || err != wantErr {
+		t.Fatalf("expected 0 and %v, got %v and %v", wantErr, n, err)
+	}
+	r := &mockReader{[]mockReaderResult{{warnings.New("a"), nil}, {nil, wantErr}}}
+	if n, err := warnings.Copy(&mockWriter{}, r); n != 1 || err != wantErr {
+		t.Fatalf("expected 1 and %v, got %v and %v", wantErr, n, err)
+	}
+}
+
+func TestReplay(t *testing.T) {
+	w := &mockWriter{}
+	ctx := warnings.Attach(context.Background(), w)
+	ctx = warnings.Scope(ctx, "parent")
+	ctx = warnings.Strict(ctx, warnings.StrictPolicy{Selector: warnings.Selector{Codes: []string{"E*"}}})
+	child := warnings.NewCollector()
+	childCtx := warnings.Scope(warnings.Attach(context.Background(), child), "child")
+	warnings.Warnf(childCtx, "a")
+	warnings.Warn(childCtx, warnings.WithCode(warnings.New("b"), "E1"))
+	warnings.Warnf(childCtx, "c")
+	n, err := warnings.Replay(ctx, child)
+	if n != 3 {
+		t.Fatalf("expected 3 warnings, got %v", n)
+	}
+	if !errors.Is(err, warnings.ErrStrict) {
+		t.Fatalf("expected %v, got %v", warnings.ErrStrict, err)
+	}
+	if len(w.buf) != 2 || w.buf[1].Warn() != "c" {
+		t.Fatalf("expected [a c], got %v", w.buf)
+	}
+	if got := warnings.ScopeOf(w.buf[0]); got != "child" {
+		t.Fatalf("expected child, got %v", got)
+	}
+}
+
+func TestReplay_ReadError(t *testing.T) {
+	wantErr := fmt.Errorf("test-error")
+	r := &mockReader{[]mockReaderResult{{warnings.New("a"), nil}, {nil, wantErr}}}
+	if n, err := warnings.Replay(context.Background(), r); n != 1 || err != wantErr {
+		t.Fatalf("expected 1 and %v, got %v and %v", wantErr, n, err)
+	}
+}
@@ -0,0 +1,91 @@
+package warnings
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// Layer kinds reported by [Describe].
+const (
+	KindAttach = "attach"
+	KindDetach = "detach"
+	KindMap    = "map"
+	KindFilter = "filter"
+	KindTap    = "tap"
+	KindReduce = "reduce"
+	KindStrict = "strict"
+	KindPolicy = "policy"
+	KindStamp  = "stamp"
+	KindStack  = "stack"
+	KindBegin  = "begin"
+	KindGroup  = "group"
+	KindRoute  = "route"
+)
+
+// Layer describes a layer of the warning pipeline of a context, see [Describe].
+type Layer struct {
+	// Kind is the kind of the layer, e.g. [KindFilter].
+	Kind string
+	// Name is the optional name given to the layer at creation, see [Named].
+	Name string
+	// Writer is the writer attached by an [KindAttach] layer.
+	Writer Writer
+	// Next is the layer the warnings go to after this one, or nil if this layer is the last.
+	// Layers that do not propagate warnings, like [KindDetach], have no next layer.
+	Next *Layer
+}
+
+// LayerOption configures a layer created by a helper, such as [Map] or [Filter].
+type LayerOption func(l *Layer)
+
+// Named returns an option that names a layer, so that it can be identified by [Describe].
+func Named(name string) LayerOption {
+	return func(l *Layer) {
+		l.Name = name
+	}
+}
+
+// attached returns an option that records the writer attached by a layer.
+func attached(w Writer) LayerOption {
+	return func(l *Layer) {
+		l.Writer = w
+	}
+}
+
+func newLayer(kind string, next *Layer, opts []LayerOption) *Layer {
+	l := &Layer{Kind: kind, Next: next}
+	for _, opt := range opts {
+		opt(l)
+	}
+	return l
+}
+
+// Describe returns the last layer of the warning pipeline of the context, or nil if there is none.
+// Layers are linked to the layers the warnings go to next, up to the root context,
+// which helps to find out which layer drops a warning or whether a [Detach] happened upstream.
+// The returned layers must not be modified.
+//
+//	fmt.Print(warnings.Describe(ctx))
+func Describe(ctx context.Context) *Layer {
+	return getPipeline(ctx).layer
+}
+
+// String returns the layer and the next layers as a tree, one layer per line.
+func (l *Layer) String() string {
+	var b strings.Builder
+	for indent := ""; l != nil; l, indent = l.Next, indent+"    " {
+		if indent != "" {
+			b.WriteString(indent[4:] + "└── ")
+		}
+		b.WriteString(l.Kind)
+		if l.Name != "" {
+			fmt.Fprintf(&b, " %q", l.Name)
+		}
+		if l.Writer != nil {
+			fmt.Fprintf(&b, " (%T)", l.Writer)
+		}
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
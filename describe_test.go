@@ -0,0 +1,60 @@
+package warnings_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/runbed/warnings"
+)
+
+// ExampleDescribe demonstrates how to use the Describe function to inspect the layers of a context.
+func ExampleDescribe() {
+	// create a new collector
+	collector := warnings.NewCollector()
+	defer collector.Close() // make sure to close the collector when done
+	// attach the collector to a context and add some layers
+	ctx := warnings.Attach(context.Background(), collector, warnings.Named("report"))
+	ctx = warnings.Filter(ctx, func(wrr warnings.Warning) bool {
+		return wrr.Warn() != "ignore"
+	}, warnings.Named("ignore"))
+	ctx = warnings.Tap(ctx, func(wrr warnings.Warning) {})
+	// print the layers, starting with the last one
+	fmt.Print(warnings.Describe(ctx))
+	// Output:
+	// tap
+	// └── filter "ignore"
+	//     └── attach "report" (*warnings.Collector)
+}
+
+func TestDescribe(t *testing.T) {
+	ctx := context.Background()
+	if l := warnings.Describe(ctx); l != nil {
+		t.Fatalf("expected no layer, got %v", l)
+	}
+	w := &mockWriter{}
+	ctx = warnings.Attach(ctx, w)
+	ctx = warnings.Map(ctx, func(wrr warnings.Warning) warnings.Warning { return wrr }, warnings.Named("identity"))
+	ctx = warnings.Detach(ctx)
+	ctx = warnings.Attach(ctx, w, warnings.Named("again"))
+	ctx, _ = warnings.Reduce(ctx, func(acc *multiWarn, wrr warnings.Warning) *multiWarn { return acc })
+	ctx, _ = warnings.Begin(ctx)
+	ctx = warnings.Strict(ctx, warnings.StrictPolicy{})
+	l := warnings.Describe(ctx)
+	var kinds []string
+	for ; l != nil; l = l.Next {
+		kinds = append(kinds, l.Kind)
+	}
+	if got, want := fmt.Sprint(kinds), "[strict begin reduce attach detach]"; got != want {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	want := `strict
+└── begin
+    └── reduce
+        └── attach "again" (*warnings_test.mockWriter)
+            └── detach
+`
+	if got := warnings.Describe(ctx).String(); got != want {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+}
@@ -5,4 +5,8 @@ import "fmt"
 var (
 	// ErrClosed is returned when the warning stream is closed.
 	ErrClosed = fmt.Errorf("warning stream is closed")
+	// ErrQueueFull is returned when a warning is dropped because a queue is full.
+	ErrQueueFull = fmt.Errorf("warning queue is full")
+	// ErrStrict is matched by the errors returned for warnings treated as errors.
+	ErrStrict = fmt.Errorf("warning treated as error")
 )
@@ -0,0 +1,107 @@
+package warnings
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// Group runs goroutines and collects their warnings deterministically, like errgroup.Group.
+// Each goroutine writes its warnings to its own staging buffer, and [Group.Wait] merges
+// the buffers in launch order, regardless of the order in which the goroutines ran.
+type Group struct {
+	ctx    context.Context
+	cancel context.CancelCauseFunc
+	w      Writer
+	wg     sync.WaitGroup
+	sem    chan struct{}
+
+	mtx    sync.Mutex
+	staged []*Collector
+	err    error
+}
+
+// NewGroup returns a new Group whose goroutines run under a context derived from ctx.
+// The derived context is canceled the first time a goroutine returns an error or when [Group.Wait] returns.
+func NewGroup(ctx context.Context) *Group {
+	ctx, cancel := context.WithCancelCause(ctx)
+	return &Group{ctx: ctx, cancel: cancel, w: getWriter(ctx)}
+}
+
+// SetLimit limits the number of active goroutines to n. A negative value means no limit.
+// It must not be called while goroutines are active.
+func (g *Group) SetLimit(n int) {
+	if n < 0 {
+		g.sem = nil
+		return
+	}
+	g.sem = make(chan struct{}, n)
+}
+
+// Go calls fn in a new goroutine with a context whose warnings are staged in the group.
+// If the number of active goroutines reached the limit, it blocks until one of them returns.
+func (g *Group) Go(fn func(ctx context.Context) error) {
+	if g.sem != nil {
+		g.sem <- struct{}{}
+	}
+	staged := NewCollector()
+	g.mtx.Lock()
+	g.staged = append(g.staged, staged)
+	g.mtx.Unlock()
+	ctx := setWriter(g.ctx, staged, KindGroup, nil)
+	g.wg.Add(1)
+	go func() {
+		defer g.done()
+		if err := fn(ctx); err != nil {
+			g.fail(err)
+		}
+	}()
+}
+
+func (g *Group) done() {
+	if g.sem != nil {
+		<-g.sem
+	}
+	g.wg.Done()
+}
+
+func (g *Group) fail(err error) {
+	g.mtx.Lock()
+	defer g.mtx.Unlock()
+	if g.err == nil {
+		g.err = err
+		g.cancel(err)
+	}
+}
+
+// Wait waits for all the goroutines to return and returns their warnings in launch order,
+// along with the first error returned by a goroutine.
+// The warnings are also written to the writer attached to the parent context, if any;
+// if any of them fail to write, the errors are joined to the returned error.
+func (g *Group) Wait() ([]Warning, error) {
+	g.wg.Wait()
+	g.cancel(nil)
+	g.mtx.Lock()
+	defer g.mtx.Unlock()
+	var (
+		result []Warning
+		errs   []error
+	)
+	for _, staged := range g.staged {
+		wrrs, _ := staged.drain()
+		for _, wrr := range wrrs {
+			if g.w == nil {
+				continue
+			}
+			if err := g.w.WriteWarning(wrr); err != nil {
+				errs = append(errs, err)
+			}
+		}
+		result = append(result, wrrs...)
+	}
+	g.staged = nil
+	if len(errs) == 0 {
+		return result, g.err
+	}
+	return result, errors.Join(append([]error{g.err}, errs...)...)
+}
@@ -0,0 +1,135 @@
+package warnings_test
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/runbed/warnings"
+)
+
+// ExampleGroup demonstrates how to use a Group to collect warnings from goroutines in launch order.
+func ExampleGroup() {
+	// create a new collector
+	collector := warnings.NewCollector()
+	defer collector.Close() // make sure to close the collector when done
+	// attach the collector to a context
+	ctx := warnings.Attach(context.Background(), collector)
+	// fan out work to goroutines
+	g := warnings.NewGroup(ctx)
+	for i := 3; i > 0; i-- {
+		g.Go(func(ctx context.Context) error {
+			// the last goroutine finishes first
+			time.Sleep(time.Duration(i) * time.Millisecond)
+			warnings.Warnf(ctx, "this is a warning from goroutine %d", i)
+			return nil
+		})
+	}
+	// wait for the goroutines, the warnings are written to the collector in launch order
+	if _, err := g.Wait(); err != nil {
+		// handle error
+	}
+	// read all warnings from the collector
+	wrrs, err := warnings.ReadAll(collector)
+	if err != nil {
+		// handle error
+	}
+	for _, wrr := range wrrs {
+		fmt.Println(wrr.Warn())
+	}
+	// Output:
+	// this is a warning from goroutine 3
+	// this is a warning from goroutine 2
+	// this is a warning from goroutine 1
+}
+
+func TestGroup(t *testing.T) {
+	w := &mockWriter{}
+	ctx := warnings.Attach(context.Background(), w)
+	g := warnings.NewGroup(ctx)
+	for i := 0; i < 10; i++ {
+		g.Go(func(ctx context.Context) error {
+			warnings.Warnf(ctx, "test-%d-a", i)
+			warnings.Warnf(ctx, "test-%d-b", i)
+			return nil
+		})
+	}
+	wrrs, err := g.Wait()
+	if err != nil {
+		t.Fatalf("expected nil, got %v", err)
+	}
+	if len(wrrs) != 20 || len(w.buf) != 20 {
+		t.Fatalf("expected 20 warnings, got %v and %v", wrrs, w.buf)
+	}
+	for i := 0; i < 10; i++ {
+		for j, suffix := range []string{"a", "b"} {
+			want := fmt.Sprintf("test-%d-%s", i, suffix)
+			if got := wrrs[2*i+j].Warn(); got != want {
+				t.Fatalf("expected %v, got %v", want, got)
+			}
+			if got := w.buf[2*i+j].Warn(); got != want {
+				t.Fatalf("expected %v, got %v", want, got)
+			}
+		}
+	}
+}
+
+func TestGroup_Error(t *testing.T) {
+	wantErr := fmt.Errorf("test-error")
+	g := warnings.NewGroup(context.Background())
+	g.Go(func(ctx context.Context) error {
+		warnings.Warnf(ctx, "test")
+		return wantErr
+	})
+	g.Go(func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	wrrs, err := g.Wait()
+	if err != wantErr {
+		t.Fatalf("expected %v, got %v", wantErr, err)
+	}
+	if len(wrrs) != 1 {
+		t.Fatalf("expected 1 warning, got %v", wrrs)
+	}
+}
+
+func TestGroup_WriteError(t *testing.T) {
+	wantErr := fmt.Errorf("test-error")
+	ctx := warnings.Attach(context.Background(), &mockWriter{result: wantErr})
+	g := warnings.NewGroup(ctx)
+	g.Go(func(ctx context.Context) error {
+		return warnings.Warnf(ctx, "test")
+	})
+	if _, err := g.Wait(); err == nil {
+		t.Fatalf("expected %v, got nil", wantErr)
+	}
+}
+
+func TestGroup_SetLimit(t *testing.T) {
+	var active, peak atomic.Int32
+	g := warnings.NewGroup(context.Background())
+	g.SetLimit(2)
+	for i := 0; i < 10; i++ {
+		g.Go(func(ctx context.Context) error {
+			n := active.Add(1)
+			defer active.Add(-1)
+			for {
+				p := peak.Load()
+				if n <= p || peak.CompareAndSwap(p, n) {
+					break
+				}
+			}
+			time.Sleep(time.Millisecond)
+			return nil
+		})
+	}
+	if _, err := g.Wait(); err != nil {
+		t.Fatalf("expected nil, got %v", err)
+	}
+	if got := peak.Load(); got > 2 {
+		t.Fatalf("expected at most 2 active goroutines, got %v", got)
+	}
+}
@@ -5,56 +5,47 @@ import (
 )
 
 // Map returns a new context that transforms each written warning using the provided function.
-func Map(ctx context.Context, fn func(wrr Warning) Warning) context.Context {
-	w := getWriter(ctx)
-	if w == nil {
+// If the function returns nil, the warning is dropped.
+func Map(ctx context.Context, fn func(wrr Warning) Warning, opts ...LayerOption) context.Context {
+	if getWriter(ctx) == nil {
 		return ctx
 	}
-	ctx = resetWriter(ctx, &mapWriter{w: w, fn: fn})
-	return ctx
-}
-
-type mapWriter struct {
-	w  Writer
-	fn func(wrr Warning) Warning
+	return addStage(ctx, KindMap, opts, mapStage(fn))
 }
 
-func (mw *mapWriter) WriteWarning(wrr Warning) error {
-	return mw.w.WriteWarning(mw.fn(wrr))
+func mapStage(fn func(wrr Warning) Warning) func(wrr Warning) (Warning, error) {
+	return func(wrr Warning) (Warning, error) {
+		return fn(wrr), nil
+	}
 }
 
 // Filter returns a new context that filters written warnings using the provided function.
-func Filter(ctx context.Context, fn func(wrr Warning) bool) context.Context {
-	w := getWriter(ctx)
-	if w == nil {
+func Filter(ctx context.Context, fn func(wrr Warning) bool, opts ...LayerOption) context.Context {
+	if getWriter(ctx) == nil {
 		return ctx
 	}
-	ctx = resetWriter(ctx, &filterWriter{w: w, fn: fn})
-	return ctx
+	return addStage(ctx, KindFilter, opts, filterStage(fn))
 }
 
-type filterWriter struct {
-	w  Writer
-	fn func(wrr Warning) bool
-}
-
-func (fw *filterWriter) WriteWarning(wrr Warning) error {
-	if fw.fn(wrr) {
-		return fw.w.WriteWarning(wrr)
+func filterStage(fn func(wrr Warning) bool) func(wrr Warning) (Warning, error) {
+	return func(wrr Warning) (Warning, error) {
+		if !fn(wrr) {
+			return nil, nil
+		}
+		return wrr, nil
 	}
-	return nil
 }
 
 // Reduce returns a new context that reduces written warnings using the provided function.
 // It also returns a flush() function that once called, writes the reduced warning to the underlying writer.
 // If no warnings are written, it does nothing.
-func Reduce[T Warning](ctx context.Context, fn func(acc T, wrr Warning) T) (_ context.Context, flush func()) {
+func Reduce[T Warning](ctx context.Context, fn func(acc T, wrr Warning) T, opts ...LayerOption) (_ context.Context, flush func()) {
 	w := getWriter(ctx)
 	if w == nil {
 		return ctx, func() {}
 	}
 	input := NewCollector()
-	ctx = resetWriter(ctx, input)
+	ctx = setWriter(ctx, input, KindReduce, opts)
 	return ctx, func() {
 		defer input.Close()
 		acc := *new(T)
@@ -71,21 +62,16 @@ func Reduce[T Warning](ctx context.Context, fn func(acc T, wrr Warning) T) (_ co
 
 // Tap returns a new context that taps written warnings using the provided function.
 // It does not modify the warnings or the context but is useful for side effects like logging.
-func Tap(ctx context.Context, fn func(wrr Warning)) context.Context {
-	w := getWriter(ctx)
-	if w == nil {
+func Tap(ctx context.Context, fn func(wrr Warning), opts ...LayerOption) context.Context {
+	if getWriter(ctx) == nil {
 		return ctx
 	}
-	ctx = resetWriter(ctx, &tapWriter{w: w, fn: fn})
-	return ctx
+	return addStage(ctx, KindTap, opts, tapStage(fn))
 }
 
-type tapWriter struct {
-	w  Writer
-	fn func(wrr Warning)
-}
-
-func (tw *tapWriter) WriteWarning(wrr Warning) error {
-	tw.fn(wrr)
-	return tw.w.WriteWarning(wrr)
+func tapStage(fn func(wrr Warning)) func(wrr Warning) (Warning, error) {
+	return func(wrr Warning) (Warning, error) {
+		fn(wrr)
+		return wrr, nil
+	}
 }
@@ -149,6 +149,24 @@ func TestMap(t *testing.T) {
 	}
 }
 
+func TestMap_Nil(t *testing.T) {
+	w := &mockWriter{}
+	ctx := warnings.Attach(context.Background(), w)
+	ctx = warnings.Map(ctx, func(wrr warnings.Warning) warnings.Warning {
+		if wrr.Warn() == "drop" {
+			return nil
+		}
+		return wrr
+	})
+	if err := warnings.Warnf(ctx, "drop"); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	warnings.Warnf(ctx, "keep")
+	if len(w.buf) != 1 || w.buf[0].Warn() != "keep" {
+		t.Fatalf("expected [keep], got %v", w.buf)
+	}
+}
+
 func TestMapNoWriter(t *testing.T) {
 	ctx := warnings.Map(context.Background(), func(wrr warnings.Warning) warnings.Warning {
 		return warnings.New(strings.ToUpper(wrr.Warn()))
@@ -0,0 +1,133 @@
+package warnings
+
+import (
+	"encoding/json"
+	"io"
+	"log/slog"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// jsonWarning is the JSON encoding of the warnings carrying metadata.
+// Warnings without metadata created by [New] are encoded as a JSON string.
+type jsonWarning struct {
+	Warning  string         `json:"warning"`
+	Scope    string         `json:"scope,omitempty"`
+	Severity Severity       `json:"severity,omitempty"`
+	Code     string         `json:"code,omitempty"`
+	Category string         `json:"category,omitempty"`
+	Attrs    map[string]any `json:"attrs,omitempty"`
+	Seq      uint64         `json:"seq,omitempty"`
+	Time     *time.Time     `json:"time,omitempty"`
+}
+
+func marshalJSON(wrr Warning) ([]byte, error) {
+	jw := jsonWarning{
+		Warning:  wrr.Warn(),
+		Scope:    ScopeOf(wrr),
+		Code:     CodeOf(wrr),
+		Category: CategoryOf(wrr),
+	}
+	if s, ok := find[interface{ Severity() Severity }](wrr); ok {
+		jw.Severity = s.Severity()
+	}
+	if attrs := AttrsOf(wrr); len(attrs) > 0 {
+		jw.Attrs = make(map[string]any, len(attrs))
+		for _, attr := range attrs {
+			jw.Attrs[attr.Key] = attr.Value.Resolve().Any()
+		}
+	}
+	if s, ok := StampOf(wrr); ok {
+		t := s.Time()
+		jw.Seq, jw.Time = s.Seq(), &t
+	}
+	return json.Marshal(jw)
+}
+
+// DecodeJSON decodes a warning from its JSON encoding.
+// The metadata of the warning, such as its scope, severity, code, category, attributes and stamp, are preserved.
+// Attribute values are decoded as the corresponding JSON values.
+func DecodeJSON(data []byte) (Warning, error) {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		return New(s), nil
+	}
+	var jw jsonWarning
+	if err := json.Unmarshal(data, &jw); err != nil {
+		return nil, err
+	}
+	wrr := New(jw.Warning)
+	if jw.Severity != 0 {
+		wrr = WithSeverity(wrr, jw.Severity)
+	}
+	if jw.Code != "" {
+		wrr = WithCode(wrr, jw.Code)
+	}
+	if jw.Category != "" {
+		wrr = WithCategory(wrr, jw.Category)
+	}
+	wrr = withScope(wrr, jw.Scope)
+	if len(jw.Attrs) > 0 {
+		attrs := make([]slog.Attr, 0, len(jw.Attrs))
+		for key, value := range jw.Attrs {
+			attrs = append(attrs, slog.Any(key, value))
+		}
+		slices.SortFunc(attrs, func(a, b slog.Attr) int {
+			return strings.Compare(a.Key, b.Key)
+		})
+		wrr = withAttrs(wrr, attrs)
+	}
+	if jw.Seq != 0 || jw.Time != nil {
+		var t time.Time
+		if jw.Time != nil {
+			t = *jw.Time
+		}
+		wrr = &stampedWarning{wrr, jw.Seq, t}
+	}
+	return wrr, nil
+}
+
+type jsonReader struct {
+	dec *json.Decoder
+}
+
+// NewJSONReader returns a reader that decodes a stream of JSON encoded warnings from r, see [DecodeJSON].
+// It returns [io.EOF] at the end of the stream.
+func NewJSONReader(r io.Reader) Reader {
+	return &jsonReader{json.NewDecoder(r)}
+}
+
+func (r *jsonReader) ReadWarning() (Warning, error) {
+	var data json.RawMessage
+	if err := r.dec.Decode(&data); err != nil {
+		return nil, err
+	}
+	return DecodeJSON(data)
+}
+
+type jsonWriter struct {
+	mtx sync.Mutex
+	enc *json.Encoder
+}
+
+// NewJSONWriter returns a writer that encodes warnings to w as JSON, one per line.
+// The stream can be read back with [NewJSONReader]. The writer is thread-safe.
+func NewJSONWriter(w io.Writer) Writer {
+	return &jsonWriter{enc: json.NewEncoder(w)}
+}
+
+func (w *jsonWriter) WriteWarning(wrr Warning) error {
+	var v any = wrr
+	if _, ok := wrr.(json.Marshaler); !ok {
+		data, err := marshalJSON(wrr)
+		if err != nil {
+			return err
+		}
+		v = json.RawMessage(data)
+	}
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+	return w.enc.Encode(v)
+}
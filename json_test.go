@@ -0,0 +1,116 @@
+package warnings_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/runbed/warnings"
+)
+
+func TestDecodeJSON(t *testing.T) {
+	now := time.Date(2024, 4, 13, 0, 0, 0, 0, time.UTC)
+	w := &mockWriter{}
+	ctx := warnings.Attach(context.Background(), w)
+	ctx = warnings.Stamp(ctx, func() time.Time { return now })
+	ctx = warnings.WithAttrs(warnings.Scope(ctx, "load"), slog.String("job", "import"), slog.Int("n", 1))
+	wrr := warnings.WithCategory(warnings.WithCode(warnings.WithSeverity(warnings.New("test"), warnings.SeverityHigh), "W1"), "deprecation")
+	warnings.Warn(ctx, wrr)
+	data, err := json.Marshal(w.buf[0])
+	if err != nil {
+		t.Fatalf("expected nil, got %v", err)
+	}
+	got, err := warnings.DecodeJSON(data)
+	if err != nil {
+		t.Fatalf("expected nil, got %v", err)
+	}
+	if got.Warn() != "test" {
+		t.Errorf("expected test, got %v", got.Warn())
+	}
+	if s := warnings.SeverityOf(got); s != warnings.SeverityHigh {
+		t.Errorf("expected %v, got %v", warnings.SeverityHigh, s)
+	}
+	if code := warnings.CodeOf(got); code != "W1" {
+		t.Errorf("expected W1, got %v", code)
+	}
+	if category := warnings.CategoryOf(got); category != "deprecation" {
+		t.Errorf("expected deprecation, got %v", category)
+	}
+	if scope := warnings.ScopeOf(got); scope != "load" {
+		t.Errorf("expected load, got %v", scope)
+	}
+	if attrs := fmt.Sprint(warnings.AttrsOf(got)); attrs != "[job=import n=1]" {
+		t.Errorf("expected [job=import n=1], got %v", attrs)
+	}
+	want, _ := warnings.StampOf(w.buf[0])
+	stamp, ok := warnings.StampOf(got)
+	if !ok {
+		t.Fatalf("expected a stamped warning")
+	}
+	if stamp.Seq() != want.Seq() || !stamp.Time().Equal(now) {
+		t.Errorf("expected %v %v, got %v %v", want.Seq(), now, stamp.Seq(), stamp.Time())
+	}
+	again, err := json.Marshal(got)
+	if err != nil {
+		t.Fatalf("expected nil, got %v", err)
+	}
+	if string(again) != string(data) {
+		t.Errorf("expected %s, got %s", data, again)
+	}
+}
+
+func TestDecodeJSON_String(t *testing.T) {
+	got, err := warnings.DecodeJSON([]byte(`"test"`))
+	if err != nil {
+		t.Fatalf("expected nil, got %v", err)
+	}
+	if got.Warn() != "test" {
+		t.Fatalf("expected test, got %v", got.Warn())
+	}
+}
+
+func TestDecodeJSON_Error(t *testing.T) {
+	for _, data := range []string{`42`, `{"severity":"unknown"}`, `{`} {
+		if _, err := warnings.DecodeJSON([]byte(data)); err == nil {
+			t.Errorf("%s: expected error, got nil", data)
+		}
+	}
+}
+
+func TestJSONWriter(t *testing.T) {
+	var buf strings.Builder
+	w := warnings.NewJSONWriter(&buf)
+	for _, wrr := range []warnings.Warning{
+		warnings.New("a"),
+		warnings.WithCode(warnings.New("b"), "W1"),
+		&multiWarn{[]string{"c", "d"}},
+	} {
+		if err := w.WriteWarning(wrr); err != nil {
+			t.Fatalf("expected nil, got %v", err)
+		}
+	}
+	want := `"a"
+{"warning":"b","code":"W1"}
+{"warning":"c, d"}
+`
+	if got := buf.String(); got != want {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	wrrs, err := warnings.ReadAll(warnings.NewJSONReader(strings.NewReader(want)))
+	if err != nil {
+		t.Fatalf("expected nil, got %v", err)
+	}
+	if len(wrrs) != 3 || warnings.CodeOf(wrrs[1]) != "W1" || wrrs[2].Warn() != "c, d" {
+		t.Fatalf("expected [a b c, d], got %v", wrrs)
+	}
+}
+
+func TestJSONReader_Error(t *testing.T) {
+	if _, err := warnings.NewJSONReader(strings.NewReader("{")).ReadWarning(); err == nil {
+		t.Fatalf("expected error, got nil")
+	}
+}
@@ -0,0 +1,160 @@
+package warnings
+
+import (
+	"io"
+	"slices"
+	"sync"
+	"time"
+)
+
+// KeyedOptions configures a [KeyedCollector].
+type KeyedOptions struct {
+	// Key returns the key of a warning, e.g. [KeyFromAttr]. If nil, warnings are keyed by their scope, see [ScopeOf].
+	Key func(wrr Warning) string
+	// TTL is the duration after which a key that is neither written nor read is evicted,
+	// along with its unread warnings. Zero means keys are never evicted.
+	TTL time.Duration
+	// Now returns the current time. If nil, [time.Now] is used.
+	Now func() time.Time
+}
+
+// KeyFromAttr returns a key function for [KeyedOptions] that reads the value of the attribute with the given name,
+// or an empty string if the warning has no such attribute. Use [WithAttrs] to tag the warnings of a context:
+//
+//	ctx = warnings.WithAttrs(ctx, slog.String("tenant", tenant))
+func KeyFromAttr(name string) func(wrr Warning) string {
+	return func(wrr Warning) string {
+		for _, attr := range AttrsOf(wrr) {
+			if attr.Key == name {
+				return attr.Value.Resolve().String()
+			}
+		}
+		return ""
+	}
+}
+
+// KeyedCollector separates warnings by key, e.g. per tenant of a multi-tenant service,
+// with a [Collector] lazily created for each key.
+// It implements the [Writer] and [io.Closer] interfaces.
+// The keyed collector is thread-safe.
+type KeyedCollector struct {
+	opts   KeyedOptions
+	mtx    sync.Mutex
+	keys   map[string]*keyedEntry
+	swept  time.Time // last time idle keys were evicted
+	closed bool
+}
+
+type keyedEntry struct {
+	c    *Collector
+	used time.Time
+}
+
+// NewKeyedCollector returns a new KeyedCollector.
+func NewKeyedCollector(opts KeyedOptions) *KeyedCollector {
+	if opts.Key == nil {
+		opts.Key = ScopeOf
+	}
+	if opts.Now == nil {
+		opts.Now = time.Now
+	}
+	return &KeyedCollector{opts: opts, keys: make(map[string]*keyedEntry), swept: opts.Now()}
+}
+
+// WriteWarning writes a warning to the collector of its key.
+// Idle keys are evicted at most once per TTL while writing.
+func (k *KeyedCollector) WriteWarning(wrr Warning) error {
+	key := k.opts.Key(wrr)
+	k.mtx.Lock()
+	defer k.mtx.Unlock()
+	if k.closed {
+		return ErrClosed
+	}
+	now := k.opts.Now()
+	if k.opts.TTL > 0 && now.Sub(k.swept) >= k.opts.TTL {
+		k.evict(now)
+	}
+	e, ok := k.keys[key]
+	if !ok {
+		e = &keyedEntry{c: NewCollector()}
+		k.keys[key] = e
+	}
+	e.used = now
+	return e.c.WriteWarning(wrr)
+}
+
+// Keys returns the sorted keys of the collector.
+func (k *KeyedCollector) Keys() []string {
+	k.mtx.Lock()
+	defer k.mtx.Unlock()
+	keys := make([]string, 0, len(k.keys))
+	for key := range k.keys {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+// ReadWarning reads a warning of the given key. It returns [io.EOF] if the key has no warnings.
+func (k *KeyedCollector) ReadWarning(key string) (Warning, error) {
+	k.mtx.Lock()
+	defer k.mtx.Unlock()
+	if k.closed {
+		return nil, ErrClosed
+	}
+	e, ok := k.keys[key]
+	if !ok {
+		return nil, io.EOF
+	}
+	e.used = k.opts.Now()
+	return e.c.ReadWarning()
+}
+
+// Reader returns a reader of the warnings of the given key, see [KeyedCollector.ReadWarning].
+func (k *KeyedCollector) Reader(key string) Reader {
+	return ReaderFunc(func() (Warning, error) {
+		return k.ReadWarning(key)
+	})
+}
+
+// EvictIdle evicts the keys that were neither written nor read for the TTL, along with their unread warnings,
+// and returns the sorted evicted keys.
+func (k *KeyedCollector) EvictIdle() []string {
+	k.mtx.Lock()
+	defer k.mtx.Unlock()
+	if k.closed || k.opts.TTL <= 0 {
+		return nil
+	}
+	evicted := k.evict(k.opts.Now())
+	slices.Sort(evicted)
+	return evicted
+}
+
+// evict evicts the idle keys. The caller must hold the lock.
+func (k *KeyedCollector) evict(now time.Time) []string {
+	var evicted []string
+	for key, e := range k.keys {
+		if now.Sub(e.used) >= k.opts.TTL {
+			_ = e.c.Close()
+			delete(k.keys, key)
+			evicted = append(evicted, key)
+		}
+	}
+	k.swept = now
+	return evicted
+}
+
+// Close closes the collector and the collectors of all the keys.
+func (k *KeyedCollector) Close() error {
+	k.mtx.Lock()
+	defer k.mtx.Unlock()
+	if k.closed {
+		return ErrClosed
+	}
+	k.closed = true
+	for _, e := range k.keys {
+		_ = e.c.Close()
+	}
+	k.keys = nil
+	return nil
+}
@@ -0,0 +1,109 @@
+package warnings_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/runbed/warnings"
+)
+
+// ExampleKeyedCollector demonstrates how to separate warnings per tenant.
+func ExampleKeyedCollector() {
+	// create a new keyed collector
+	collector := warnings.NewKeyedCollector(warnings.KeyedOptions{
+		Key: warnings.KeyFromAttr("tenant"),
+		TTL: time.Hour,
+	})
+	defer collector.Close() // make sure to close the collector when done
+	// attach the collector to a context
+	ctx := warnings.Attach(context.Background(), collector)
+	for _, tenant := range []string{"acme", "globex", "acme"} {
+		tenantCtx := warnings.WithAttrs(ctx, slog.String("tenant", tenant))
+		warnings.Warnf(tenantCtx, "this is a warning for %s", tenant)
+	}
+	// read the warnings of each tenant
+	for _, tenant := range collector.Keys() {
+		wrrs, err := warnings.ReadAll(collector.Reader(tenant))
+		if err != nil {
+			// handle error
+		}
+		fmt.Println(tenant, len(wrrs))
+	}
+	// Output:
+	// acme 2
+	// globex 1
+}
+
+func TestKeyedCollector(t *testing.T) {
+	c := warnings.NewKeyedCollector(warnings.KeyedOptions{})
+	ctx := warnings.Attach(context.Background(), c)
+	warnings.Warnf(warnings.Scope(ctx, "b"), "b-1")
+	warnings.Warnf(warnings.Scope(ctx, "a"), "a-1")
+	warnings.Warnf(ctx, "none")
+	if got, want := fmt.Sprint(c.Keys()), "[ a b]"; got != want {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	if got, want := readAllStrings(t, c.Reader("a")), "[a-1]"; got != want {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	if _, err := c.ReadWarning("unknown"); err != io.EOF {
+		t.Fatalf("expected %v, got %v", io.EOF, err)
+	}
+	if err := c.Close(); err != nil {
+		t.Fatalf("expected nil, got %v", err)
+	}
+	if _, err := c.ReadWarning("b"); err != warnings.ErrClosed {
+		t.Fatalf("expected %v, got %v", warnings.ErrClosed, err)
+	}
+	if err := c.WriteWarning(warnings.New("test")); err != warnings.ErrClosed {
+		t.Fatalf("expected %v, got %v", warnings.ErrClosed, err)
+	}
+	if err := c.Close(); err != warnings.ErrClosed {
+		t.Fatalf("expected %v, got %v", warnings.ErrClosed, err)
+	}
+}
+
+func TestKeyedCollector_TTL(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := warnings.NewKeyedCollector(warnings.KeyedOptions{
+		Key: warnings.KeyFromAttr("tenant"),
+		TTL: time.Minute,
+		Now: func() time.Time { return now },
+	})
+	ctx := warnings.Attach(context.Background(), c)
+	warnA := warnings.WithAttrs(ctx, slog.String("tenant", "a"))
+	warnB := warnings.WithAttrs(ctx, slog.String("tenant", "b"))
+	warnings.Warnf(warnA, "a-1")
+	warnings.Warnf(warnB, "b-1")
+	now = now.Add(30 * time.Second)
+	c.ReadWarning("b")
+	now = now.Add(30 * time.Second)
+	if got, want := fmt.Sprint(c.EvictIdle()), "[a]"; got != want {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	now = now.Add(time.Minute)
+	warnings.Warnf(warnA, "a-2")
+	if got, want := fmt.Sprint(c.Keys()), "[a]"; got != want {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	if got, want := readAllStrings(t, c.Reader("a")), "[a-2]"; got != want {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+}
+
+func TestKeyFromAttr(t *testing.T) {
+	key := warnings.KeyFromAttr("tenant")
+	if got := key(warnings.New("test")); got != "" {
+		t.Fatalf("expected empty key, got %v", got)
+	}
+	ctx := warnings.WithAttrs(context.Background(), slog.Int("tenant", 42))
+	w := &mockWriter{}
+	warnings.Warnf(warnings.Attach(ctx, w), "test")
+	if got := key(w.buf[0]); got != "42" {
+		t.Fatalf("expected 42, got %v", got)
+	}
+}
@@ -0,0 +1,103 @@
+package warnings
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sync"
+)
+
+// Lazyf returns a warning whose message is formatted with [fmt.Sprintf] only when it is first needed,
+// e.g. when a consumer calls Warn(). Warnings dropped by a [Filter] are never formatted.
+// If the arguments contain any [Warning], they are converted to strings before formatting.
+// The warning keeps the format string and a copy of the arguments, see [TemplateOf] and [ArgsOf].
+// The values referenced by the arguments are formatted when the message is rendered,
+// so they should not be modified after the call.
+func Lazyf(format string, args ...any) Warning {
+	return &formattedWarning{format: format, args: slices.Clip(slices.Clone(args))}
+}
+
+// TemplateOf returns the format string of a formatted warning, see [Warnf] and [Lazyf].
+// Warnings that share a template are the same warning with different values, which is useful for grouping.
+// Warnings can provide their own template by implementing a Template() string method.
+// For other warnings, it returns the warning message.
+func TemplateOf(wrr Warning) string {
+	t, ok := find[interface{ Template() string }](wrr)
+	if !ok {
+		return wrr.Warn()
+	}
+	return t.Template()
+}
+
+// ArgsOf returns the arguments of a formatted warning, see [Warnf] and [Lazyf], or nil for other warnings.
+// Warnings can provide their own arguments by implementing an Args() []any method.
+func ArgsOf(wrr Warning) []any {
+	a, ok := find[interface{ Args() []any }](wrr)
+	if !ok {
+		return nil
+	}
+	return a.Args()
+}
+
+type formattedWarning struct {
+	format string
+	args   []any
+	once   sync.Once
+	s      string
+}
+
+// formatted returns a formatted warning whose message is already rendered.
+func formatted(format string, args []any, s string) *formattedWarning {
+	wrr := &formattedWarning{format: format, args: args}
+	wrr.once.Do(func() { wrr.s = s })
+	return wrr
+}
+
+// sprintf formats the arguments like [fmt.Sprintf], replacing the warnings by their message.
+// The arguments are forwarded as is when possible, so that vet checks the format strings of the callers.
+func sprintf(format string, args ...any) string {
+	if !slices.ContainsFunc(args, isWarning) {
+		return fmt.Sprintf(format, args...)
+	}
+	return fmt.Sprintf(format, warningArgs(args)...)
+}
+
+func isWarning(arg any) bool {
+	_, ok := arg.(Warning)
+	return ok
+}
+
+// warningArgs returns a copy of the arguments where the warnings are replaced by their message.
+func warningArgs(args []any) []any {
+	result := make([]any, len(args))
+	for i, arg := range args {
+		if w, ok := arg.(Warning); ok {
+			arg = w.Warn()
+		}
+		result[i] = arg
+	}
+	return result
+}
+
+func (wrr *formattedWarning) Warn() string {
+	wrr.once.Do(func() {
+		wrr.s = sprintf(wrr.format, wrr.args...)
+	})
+	return wrr.s
+}
+
+func (wrr *formattedWarning) Template() string {
+	return wrr.format
+}
+
+func (wrr *formattedWarning) Args() []any {
+	return slices.Clone(wrr.args)
+}
+
+func (wrr *formattedWarning) String() string {
+	return wrr.Warn()
+}
+
+func (wrr *formattedWarning) MarshalJSON() ([]byte, error) {
+	return json.Marshal(wrr.Warn())
+}
@@ -0,0 +1,58 @@
+package warnings_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/runbed/warnings"
+)
+
+type countingStringer struct {
+	calls int
+}
+
+func (s *countingStringer) String() string {
+	s.calls++
+	return "value"
+}
+
+func TestLazyf(t *testing.T) {
+	arg := &countingStringer{}
+	wrr := warnings.Lazyf("test: %s, %s", arg, warnings.New("sub-warning"))
+	if arg.calls != 0 {
+		t.Fatalf("expected the warning not to be formatted")
+	}
+	for i := 0; i < 2; i++ {
+		if got, want := wrr.Warn(), "test: value, sub-warning"; got != want {
+			t.Fatalf("expected %v, got %v", want, got)
+		}
+	}
+	if arg.calls != 1 {
+		t.Fatalf("expected the warning to be formatted once, got %v", arg.calls)
+	}
+	if got, want := fmt.Sprint(wrr), "test: value, sub-warning"; got != want {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	data, err := json.Marshal(wrr)
+	if err != nil {
+		t.Fatalf("expected nil, got %v", err)
+	}
+	if got, want := string(data), `"test: value, sub-warning"`; got != want {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+}
+
+func TestLazyf_Filtered(t *testing.T) {
+	arg := &countingStringer{}
+	w := &mockWriter{}
+	ctx := warnings.Attach(context.Background(), w)
+	ctx = warnings.Filter(ctx, func(wrr warnings.Warning) bool {
+		return false
+	})
+	warnings.Warn(ctx, warnings.Lazyf("test: %s", arg))
+	if arg.calls != 0 {
+		t.Fatalf("expected the warning not to be formatted")
+	}
+}
@@ -0,0 +1,178 @@
+package warnings
+
+import (
+	"fmt"
+	"path"
+	"slices"
+	"strconv"
+)
+
+// Severity is the importance of a warning.
+// The zero value means the severity is not specified, see [SeverityOf].
+type Severity int
+
+// Severity levels, from the least to the most important.
+const (
+	SeverityLow Severity = iota + 1
+	SeverityMedium
+	SeverityHigh
+	SeverityCritical
+)
+
+// String returns the name of the severity.
+func (s Severity) String() string {
+	switch s {
+	case SeverityLow:
+		return "low"
+	case SeverityMedium:
+		return "medium"
+	case SeverityHigh:
+		return "high"
+	case SeverityCritical:
+		return "critical"
+	}
+	return "severity(" + strconv.Itoa(int(s)) + ")"
+}
+
+// MarshalText implements the [encoding.TextMarshaler] interface.
+func (s Severity) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText implements the [encoding.TextUnmarshaler] interface.
+func (s *Severity) UnmarshalText(text []byte) error {
+	for candidate := SeverityLow; candidate <= SeverityCritical; candidate++ {
+		if candidate.String() == string(text) {
+			*s = candidate
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid severity: %q", text)
+}
+
+// WithSeverity returns a warning that wraps wrr and has the given severity.
+func WithSeverity(wrr Warning, severity Severity) Warning {
+	return &severityWarning{wrr, severity}
+}
+
+// SeverityOf returns the severity of the warning.
+// Warnings can carry their own severity by implementing a Severity() [Severity] method.
+// If the severity is not specified, it returns [SeverityMedium].
+func SeverityOf(wrr Warning) Severity {
+	s, ok := find[interface{ Severity() Severity }](wrr)
+	if !ok || s.Severity() == 0 {
+		return SeverityMedium
+	}
+	return s.Severity()
+}
+
+type severityWarning struct {
+	Warning
+	severity Severity
+}
+
+func (wrr *severityWarning) Severity() Severity {
+	return wrr.severity
+}
+
+func (wrr *severityWarning) Unwrap() Warning {
+	return wrr.Warning
+}
+
+func (wrr *severityWarning) MarshalJSON() ([]byte, error) {
+	return marshalJSON(wrr)
+}
+
+// WithCode returns a warning that wraps wrr and has the given code, e.g. "W1001".
+func WithCode(wrr Warning, code string) Warning {
+	return &codeWarning{wrr, code}
+}
+
+// CodeOf returns the code of the warning, or an empty string if the warning has no code.
+// Warnings can carry their own code by implementing a Code() string method.
+func CodeOf(wrr Warning) string {
+	c, ok := find[interface{ Code() string }](wrr)
+	if !ok {
+		return ""
+	}
+	return c.Code()
+}
+
+type codeWarning struct {
+	Warning
+	code string
+}
+
+func (wrr *codeWarning) Code() string {
+	return wrr.code
+}
+
+func (wrr *codeWarning) Unwrap() Warning {
+	return wrr.Warning
+}
+
+func (wrr *codeWarning) MarshalJSON() ([]byte, error) {
+	return marshalJSON(wrr)
+}
+
+// WithCategory returns a warning that wraps wrr and belongs to the given category, e.g. "deprecation".
+func WithCategory(wrr Warning, category string) Warning {
+	return &categoryWarning{wrr, category}
+}
+
+// CategoryOf returns the category of the warning, or an empty string if the warning has no category.
+// Warnings can carry their own category by implementing a Category() string method.
+func CategoryOf(wrr Warning) string {
+	c, ok := find[interface{ Category() string }](wrr)
+	if !ok {
+		return ""
+	}
+	return c.Category()
+}
+
+type categoryWarning struct {
+	Warning
+	category string
+}
+
+func (wrr *categoryWarning) Category() string {
+	return wrr.category
+}
+
+func (wrr *categoryWarning) Unwrap() Warning {
+	return wrr.Warning
+}
+
+func (wrr *categoryWarning) MarshalJSON() ([]byte, error) {
+	return marshalJSON(wrr)
+}
+
+// Selector selects warnings by severity, code or an arbitrary predicate.
+// A warning is selected when it matches any of the configured criteria.
+// The zero value selects every warning.
+type Selector struct {
+	// MinSeverity selects the warnings with at least this severity.
+	MinSeverity Severity
+	// Codes selects the warnings with one of these codes.
+	// Codes are patterns with the syntax of [path.Match], e.g. "W2*".
+	Codes []string
+	// Match selects the warnings for which it returns true.
+	Match func(wrr Warning) bool
+}
+
+// Matches reports whether the warning is selected.
+func (s Selector) Matches(wrr Warning) bool {
+	if s.MinSeverity == 0 && len(s.Codes) == 0 && s.Match == nil {
+		return true
+	}
+	if s.MinSeverity != 0 && SeverityOf(wrr) >= s.MinSeverity {
+		return true
+	}
+	if code := CodeOf(wrr); code != "" && slices.ContainsFunc(s.Codes, func(pattern string) bool {
+		ok, _ := path.Match(pattern, code)
+		return ok
+	}) {
+		return true
+	}
+	return s.Match != nil && s.Match(wrr)
+}
@@ -0,0 +1,93 @@
+package warnings_test
+
+import (
+	"testing"
+
+	"github.com/runbed/warnings"
+)
+
+func TestSeverityOf(t *testing.T) {
+	wrr := warnings.New("test")
+	if got := warnings.SeverityOf(wrr); got != warnings.SeverityMedium {
+		t.Fatalf("expected %v, got %v", warnings.SeverityMedium, got)
+	}
+	wrr = warnings.WithSeverity(wrr, warnings.SeverityHigh)
+	if got := warnings.SeverityOf(wrr); got != warnings.SeverityHigh {
+		t.Fatalf("expected %v, got %v", warnings.SeverityHigh, got)
+	}
+	if got := wrr.Warn(); got != "test" {
+		t.Fatalf("expected test, got %v", got)
+	}
+	if got := warnings.SeverityOf(warnings.WithCode(wrr, "W1")); got != warnings.SeverityHigh {
+		t.Fatalf("expected %v, got %v", warnings.SeverityHigh, got)
+	}
+}
+
+func TestSeverity_String(t *testing.T) {
+	tests := map[warnings.Severity]string{
+		warnings.SeverityLow:      "low",
+		warnings.SeverityMedium:   "medium",
+		warnings.SeverityHigh:     "high",
+		warnings.SeverityCritical: "critical",
+		warnings.Severity(42):     "severity(42)",
+	}
+	for s, want := range tests {
+		if got := s.String(); got != want {
+			t.Errorf("expected %v, got %v", want, got)
+		}
+	}
+}
+
+func TestCodeOf(t *testing.T) {
+	wrr := warnings.New("test")
+	if got := warnings.CodeOf(wrr); got != "" {
+		t.Fatalf("expected no code, got %v", got)
+	}
+	wrr = warnings.WithCode(wrr, "W1001")
+	if got := warnings.CodeOf(wrr); got != "W1001" {
+		t.Fatalf("expected W1001, got %v", got)
+	}
+	if got := warnings.CodeOf(warnings.WithSeverity(wrr, warnings.SeverityLow)); got != "W1001" {
+		t.Fatalf("expected W1001, got %v", got)
+	}
+}
+
+func TestSelector(t *testing.T) {
+	low := warnings.WithSeverity(warnings.New("low"), warnings.SeverityLow)
+	high := warnings.WithSeverity(warnings.New("high"), warnings.SeverityHigh)
+	coded := warnings.WithCode(low, "W2001")
+	tests := []struct {
+		name string
+		sel  warnings.Selector
+		wrr  warnings.Warning
+		want bool
+	}{
+		{"zero", warnings.Selector{}, low, true},
+		{"severity", warnings.Selector{MinSeverity: warnings.SeverityHigh}, high, true},
+		{"below severity", warnings.Selector{MinSeverity: warnings.SeverityHigh}, low, false},
+		{"code", warnings.Selector{Codes: []string{"W2*"}}, coded, true},
+		{"other code", warnings.Selector{Codes: []string{"W1*"}}, coded, false},
+		{"no code", warnings.Selector{Codes: []string{"*"}}, low, false},
+		{"match", warnings.Selector{Match: func(wrr warnings.Warning) bool { return wrr.Warn() == "low" }}, low, true},
+		{"any", warnings.Selector{MinSeverity: warnings.SeverityHigh, Codes: []string{"W2001"}}, coded, true},
+	}
+	for _, tt := range tests {
+		if got := tt.sel.Matches(tt.wrr); got != tt.want {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.want, got)
+		}
+	}
+}
+
+func TestCategoryOf(t *testing.T) {
+	wrr := warnings.New("test")
+	if got := warnings.CategoryOf(wrr); got != "" {
+		t.Fatalf("expected no category, got %v", got)
+	}
+	wrr = warnings.WithCategory(wrr, "deprecation")
+	if got := warnings.CategoryOf(wrr); got != "deprecation" {
+		t.Fatalf("expected deprecation, got %v", got)
+	}
+	if got := warnings.CategoryOf(warnings.WithCode(wrr, "W1")); got != "deprecation" {
+		t.Fatalf("expected deprecation, got %v", got)
+	}
+}
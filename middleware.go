@@ -0,0 +1,38 @@
+package warnings
+
+// Middleware returns a writer that processes warnings before writing them to w.
+type Middleware func(w Writer) Writer
+
+// Chain returns a writer that passes warnings through the middlewares, in order, before writing them to w.
+// It builds a pipeline without a context, so that it can be built once and attached in many places:
+//
+//	w := warnings.Chain(collector, warnings.MapW(normalize), warnings.FilterW(isRelevant))
+//	ctx = warnings.Attach(ctx, w)
+func Chain(w Writer, mws ...Middleware) Writer {
+	for i := len(mws) - 1; i >= 0; i-- {
+		w = mws[i](w)
+	}
+	return w
+}
+
+// MapW returns a middleware that transforms each warning using the provided function, like [Map].
+// If the function returns nil, the warning is dropped.
+func MapW(fn func(wrr Warning) Warning, opts ...LayerOption) Middleware {
+	return middleware(KindMap, opts, mapStage(fn))
+}
+
+// FilterW returns a middleware that filters warnings using the provided function, like [Filter].
+func FilterW(fn func(wrr Warning) bool, opts ...LayerOption) Middleware {
+	return middleware(KindFilter, opts, filterStage(fn))
+}
+
+// TapW returns a middleware that calls the provided function for each warning, like [Tap].
+func TapW(fn func(wrr Warning), opts ...LayerOption) Middleware {
+	return middleware(KindTap, opts, tapStage(fn))
+}
+
+func middleware(kind string, opts []LayerOption, fn func(wrr Warning) (Warning, error)) Middleware {
+	return func(w Writer) Writer {
+		return extend(w, stage{layer: newLayer(kind, nil, opts), fn: fn})
+	}
+}
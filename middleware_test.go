@@ -0,0 +1,83 @@
+package warnings_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/runbed/warnings"
+)
+
+// ExampleChain demonstrates how to build a pipeline once, without a context, and attach it.
+func ExampleChain() {
+	// create a new collector
+	collector := warnings.NewCollector()
+	defer collector.Close() // make sure to close the collector when done
+	// build the pipeline
+	w := warnings.Chain(collector,
+		warnings.FilterW(func(wrr warnings.Warning) bool {
+			return !strings.HasPrefix(wrr.Warn(), "ignore")
+		}),
+		warnings.MapW(func(wrr warnings.Warning) warnings.Warning {
+			return warnings.New(strings.ToUpper(wrr.Warn()))
+		}),
+	)
+	// attach the pipeline to a context
+	ctx := warnings.Attach(context.Background(), w)
+	warnings.Warnf(ctx, "this is a warning")
+	warnings.Warnf(ctx, "ignore this warning")
+	// read all warnings from the collector
+	wrrs, err := warnings.ReadAll(collector)
+	if err != nil {
+		// handle error
+	}
+	for _, wrr := range wrrs {
+		fmt.Println(wrr.Warn())
+	}
+	// Output:
+	// THIS IS A WARNING
+}
+
+func TestChain(t *testing.T) {
+	var got []string
+	w := &mockWriter{}
+	chained := warnings.Chain(w,
+		warnings.TapW(func(wrr warnings.Warning) { got = append(got, "tap-1:"+wrr.Warn()) }),
+		warnings.MapW(func(wrr warnings.Warning) warnings.Warning { return warnings.New(wrr.Warn() + "!") }),
+		warnings.TapW(func(wrr warnings.Warning) { got = append(got, "tap-2:"+wrr.Warn()) }),
+		warnings.FilterW(func(wrr warnings.Warning) bool { return wrr.Warn() != "ignore!" }),
+	)
+	chained.WriteWarning(warnings.New("test"))
+	chained.WriteWarning(warnings.New("ignore"))
+	if want := "[tap-1:test tap-2:test! tap-1:ignore tap-2:ignore!]"; fmt.Sprint(got) != want {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	if len(w.buf) != 1 || w.buf[0].Warn() != "test!" {
+		t.Fatalf("expected [test!], got %v", w.buf)
+	}
+}
+
+func TestChain_Empty(t *testing.T) {
+	w := &mockWriter{}
+	if got := warnings.Chain(w); got != w {
+		t.Fatalf("expected the same writer, got %v", got)
+	}
+}
+
+func TestChain_Reuse(t *testing.T) {
+	w := &mockWriter{}
+	chained := warnings.Chain(w, warnings.FilterW(func(wrr warnings.Warning) bool {
+		return wrr.Warn() != "ignore"
+	}))
+	for _, ctx := range []context.Context{
+		warnings.Attach(context.Background(), chained),
+		warnings.Attach(warnings.Scope(context.Background(), "other"), chained),
+	} {
+		warnings.Warnf(ctx, "test")
+		warnings.Warnf(ctx, "ignore")
+	}
+	if len(w.buf) != 2 {
+		t.Fatalf("expected 2 warnings, got %v", w.buf)
+	}
+}
@@ -0,0 +1,59 @@
+package warnings
+
+import (
+	"encoding/json"
+	"runtime"
+	"time"
+)
+
+// originOptions is what [Warn] records about the writing of a warning, for the layers that need it
+// even when the warning reaches them later, e.g. when it is staged by [Begin] and written by [Tx.Commit].
+type originOptions struct {
+	depth int              // number of program counters to record, see WithPolicy and CaptureStack
+	clock func() time.Time // the clock of the last stamping layer, see Stamp
+}
+
+// originWarning records how a warning was written. It is not visible through the API,
+// the layers use it to tag the warning as if they ran when it was written.
+type originWarning struct {
+	Warning
+	pcs     []uintptr
+	seq     uint64
+	time    time.Time
+	stamped bool
+}
+
+// withOrigin records the origin of the warning written by the caller of [Warn].
+// A warning that already has an origin, e.g. when it is replayed, keeps it.
+func withOrigin(wrr Warning, opts originOptions) Warning {
+	if wrr == nil || opts.depth == 0 && opts.clock == nil {
+		return wrr
+	}
+	prev, _ := find[*originWarning](wrr)
+	if prev != nil && len(prev.pcs) >= opts.depth && (prev.stamped || opts.clock == nil) {
+		return wrr
+	}
+	o := &originWarning{Warning: wrr}
+	if prev != nil {
+		o.pcs, o.seq, o.time, o.stamped = prev.pcs, prev.seq, prev.time, prev.stamped
+	}
+	if len(o.pcs) < opts.depth {
+		pcs := make([]uintptr, opts.depth)
+		o.pcs = pcs[:runtime.Callers(3, pcs)]
+	}
+	if !o.stamped && opts.clock != nil {
+		if _, ok := StampOf(wrr); !ok {
+			o.seq, o.time, o.stamped = seq.Add(1), opts.clock(), true
+		}
+	}
+	return o
+}
+
+func (wrr *originWarning) Unwrap() Warning {
+	return wrr.Warning
+}
+
+// MarshalJSON encodes the wrapped warning, the origin is not part of the encoding.
+func (wrr *originWarning) MarshalJSON() ([]byte, error) {
+	return json.Marshal(wrr.Warning)
+}
@@ -0,0 +1,138 @@
+package warnings
+
+import (
+	"io"
+	"sync"
+)
+
+// pipe is the shared state of a [PipeReader] and a [PipeWriter].
+type pipe struct {
+	wrCh chan Warning
+
+	mtx  sync.Mutex
+	rerr error // error set by closing the reader
+	werr error // error set by closing the writer
+	once sync.Once
+	done chan struct{}
+}
+
+func (p *pipe) read() (Warning, error) {
+	select {
+	case <-p.done:
+		return nil, p.readCloseError()
+	default:
+	}
+	select {
+	case wrr := <-p.wrCh:
+		return wrr, nil
+	case <-p.done:
+		return nil, p.readCloseError()
+	}
+}
+
+func (p *pipe) write(wrr Warning) error {
+	select {
+	case <-p.done:
+		return p.writeCloseError()
+	default:
+	}
+	select {
+	case p.wrCh <- wrr:
+		return nil
+	case <-p.done:
+		return p.writeCloseError()
+	}
+}
+
+func (p *pipe) close(errp *error, err error) {
+	p.mtx.Lock()
+	if *errp == nil {
+		*errp = err
+	}
+	p.mtx.Unlock()
+	p.once.Do(func() { close(p.done) })
+}
+
+// readCloseError returns the error of the writer if only the writer is closed, [ErrClosed] otherwise.
+func (p *pipe) readCloseError() error {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	if p.rerr == nil && p.werr != nil {
+		return p.werr
+	}
+	return ErrClosed
+}
+
+// writeCloseError returns the error of the reader if only the reader is closed, [ErrClosed] otherwise.
+func (p *pipe) writeCloseError() error {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	if p.werr == nil && p.rerr != nil {
+		return p.rerr
+	}
+	return ErrClosed
+}
+
+// PipeReader is the read half of a pipe, see [Pipe].
+type PipeReader struct {
+	p *pipe
+}
+
+// ReadWarning reads a warning from the pipe. It blocks until a warning is written or the pipe is closed.
+// If the write end is closed, it returns the error passed to [PipeWriter.CloseWithError], or [io.EOF].
+func (r *PipeReader) ReadWarning() (Warning, error) {
+	return r.p.read()
+}
+
+// Close closes the reader. Subsequent writes to the write half of the pipe return [ErrClosed].
+func (r *PipeReader) Close() error {
+	return r.CloseWithError(nil)
+}
+
+// CloseWithError closes the reader. Subsequent writes to the write half of the pipe return err,
+// or [ErrClosed] if err is nil. It never overwrites the previous error if one exists and always returns nil.
+func (r *PipeReader) CloseWithError(err error) error {
+	if err == nil {
+		err = ErrClosed
+	}
+	r.p.close(&r.p.rerr, err)
+	return nil
+}
+
+// PipeWriter is the write half of a pipe, see [Pipe].
+type PipeWriter struct {
+	p *pipe
+}
+
+// WriteWarning writes a warning to the pipe. It blocks until a reader takes the warning or the pipe is closed.
+// If the read end is closed, it returns the error passed to [PipeReader.CloseWithError], or [ErrClosed].
+func (w *PipeWriter) WriteWarning(wrr Warning) error {
+	return w.p.write(wrr)
+}
+
+// Close closes the writer. Subsequent reads from the read half of the pipe return [io.EOF].
+func (w *PipeWriter) Close() error {
+	return w.CloseWithError(nil)
+}
+
+// CloseWithError closes the writer. Subsequent reads from the read half of the pipe return err,
+// or [io.EOF] if err is nil. It never overwrites the previous error if one exists and always returns nil.
+func (w *PipeWriter) CloseWithError(err error) error {
+	if err == nil {
+		err = io.EOF
+	}
+	w.p.close(&w.p.werr, err)
+	return nil
+}
+
+// Pipe creates a synchronous in-memory pipe, like [io.Pipe].
+// Each write to the [PipeWriter] blocks until a read from the [PipeReader] takes the warning,
+// which gives back-pressure between a producer and a consumer without an unbounded [Collector] in between.
+// There is no internal buffering. It is safe to read and write concurrently, and to close either end concurrently.
+func Pipe() (*PipeReader, *PipeWriter) {
+	p := &pipe{
+		wrCh: make(chan Warning),
+		done: make(chan struct{}),
+	}
+	return &PipeReader{p}, &PipeWriter{p}
+}
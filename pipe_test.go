@@ -0,0 +1,100 @@
+package warnings_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/runbed/warnings"
+)
+
+// ExamplePipe demonstrates how to consume the warnings of a producer goroutine with back-pressure.
+func ExamplePipe() {
+	pr, pw := warnings.Pipe()
+	go func() {
+		// close the writer when done, so that the reader gets io.EOF
+		defer pw.Close()
+		ctx := warnings.Attach(context.Background(), pw)
+		warnings.Warnf(ctx, "this is a warning")
+		warnings.Warnf(ctx, "this is another warning")
+	}()
+	// read all warnings until the writer is closed
+	wrrs, err := warnings.ReadAll(pr)
+	if err != nil {
+		// handle error
+	}
+	for _, wrr := range wrrs {
+		fmt.Println(wrr.Warn())
+	}
+	// Output:
+	// this is a warning
+	// this is another warning
+}
+
+func TestPipe(t *testing.T) {
+	pr, pw := warnings.Pipe()
+	written := make(chan struct{})
+	go func() {
+		defer close(written)
+		if err := pw.WriteWarning(warnings.New("test")); err != nil {
+			t.Errorf("expected nil, got %v", err)
+		}
+	}()
+	select {
+	case <-written:
+		t.Fatalf("expected the write to block until the warning is read")
+	default:
+	}
+	wrr, err := pr.ReadWarning()
+	if err != nil || wrr.Warn() != "test" {
+		t.Fatalf("expected test, got %v and %v", wrr, err)
+	}
+	<-written
+	pw.Close()
+	if _, err := pr.ReadWarning(); err != io.EOF {
+		t.Fatalf("expected %v, got %v", io.EOF, err)
+	}
+	if err := pw.WriteWarning(warnings.New("test")); err != warnings.ErrClosed {
+		t.Fatalf("expected %v, got %v", warnings.ErrClosed, err)
+	}
+}
+
+func TestPipe_CloseWriterWithError(t *testing.T) {
+	pr, pw := warnings.Pipe()
+	wantErr := fmt.Errorf("test-error")
+	read := make(chan error)
+	go func() {
+		_, err := pr.ReadWarning()
+		read <- err
+	}()
+	pw.CloseWithError(wantErr)
+	if err := <-read; err != wantErr {
+		t.Fatalf("expected %v, got %v", wantErr, err)
+	}
+	pw.CloseWithError(fmt.Errorf("other-error"))
+	if _, err := pr.ReadWarning(); err != wantErr {
+		t.Fatalf("expected %v, got %v", wantErr, err)
+	}
+}
+
+func TestPipe_CloseReader(t *testing.T) {
+	pr, pw := warnings.Pipe()
+	wantErr := fmt.Errorf("test-error")
+	written := make(chan error)
+	go func() {
+		written <- pw.WriteWarning(warnings.New("test"))
+	}()
+	pr.CloseWithError(wantErr)
+	if err := <-written; err != wantErr {
+		t.Fatalf("expected %v, got %v", wantErr, err)
+	}
+	if _, err := pr.ReadWarning(); err != warnings.ErrClosed {
+		t.Fatalf("expected %v, got %v", warnings.ErrClosed, err)
+	}
+	pr, pw = warnings.Pipe()
+	pr.Close()
+	if err := pw.WriteWarning(warnings.New("test")); err != warnings.ErrClosed {
+		t.Fatalf("expected %v, got %v", warnings.ErrClosed, err)
+	}
+}
@@ -0,0 +1,123 @@
+package warnings
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+)
+
+type pipelineKey struct{}
+
+// pipeline is the immutable state carried by a context: the writer of the warnings
+// and the metadata they are tagged with. Each helper stores a new pipeline extending
+// the one of its parent, so the state is always found in the closest context value,
+// regardless of the number of layers.
+type pipeline struct {
+	w     Writer
+	layer *Layer // the last layer, see Describe
+	audit Writer // the writer of audit events, see Audit
+	scope string
+	attrs []slog.Attr
+	// origin is what the layers need Warn to record about the writing of the warnings
+	origin originOptions
+}
+
+var emptyPipeline = new(pipeline)
+
+func getPipeline(ctx context.Context) *pipeline {
+	p, ok := ctx.Value(pipelineKey{}).(*pipeline)
+	if !ok {
+		return emptyPipeline
+	}
+	return p
+}
+
+func setPipeline(ctx context.Context, p pipeline) context.Context {
+	return context.WithValue(ctx, pipelineKey{}, &p)
+}
+
+// listening reports whether writing a warning has any effect, either writing it
+// or auditing that a [Detach] layer drops it.
+func (p *pipeline) listening() bool {
+	return p.w != nil || p.auditsDetach()
+}
+
+// auditsDetach reports whether the warnings dropped by the last layer, a [Detach], are audited.
+func (p *pipeline) auditsDetach() bool {
+	return p.audit != nil && p.layer != nil && p.layer.Kind == KindDetach
+}
+
+func getWriter(ctx context.Context) Writer {
+	return getPipeline(ctx).w
+}
+
+// setWriter returns a new context whose warnings are written to w by a new layer, replacing the current writer.
+func setWriter(ctx context.Context, w Writer, kind string, opts []LayerOption) context.Context {
+	p := *getPipeline(ctx)
+	p.w = w
+	p.layer = newLayer(kind, p.layer, opts)
+	return setPipeline(ctx, p)
+}
+
+// addStage returns a new context whose warnings go through a new layer applying fn before the current writer.
+func addStage(ctx context.Context, kind string, opts []LayerOption, fn func(wrr Warning) (Warning, error)) context.Context {
+	p := *getPipeline(ctx)
+	p.layer = newLayer(kind, p.layer, opts)
+	p.w = extend(p.w, stage{p.layer, fn, p.audit})
+	return setPipeline(ctx, p)
+}
+
+// stage is a step of a chain. Its function returns the warning to pass to the next step,
+// or nil to drop it, and an error to report.
+type stage struct {
+	layer *Layer
+	fn    func(wrr Warning) (Warning, error)
+	audit Writer // records the decisions of the stage, if not nil
+}
+
+// chain is a writer that passes warnings through a flat list of stages before writing them to w.
+// Helpers extend a chain by creating a new one with an additional first stage,
+// rather than wrapping writers in writers.
+type chain struct {
+	stages []stage
+	w      Writer // nil when the chain has no writer
+}
+
+// extend returns a writer that passes warnings through s before writing them to w.
+func extend(w Writer, s stage) Writer {
+	if c, ok := w.(*chain); ok {
+		return &chain{stages: append([]stage{s}, c.stages...), w: c.w}
+	}
+	return &chain{stages: []stage{s}, w: w}
+}
+
+func (c *chain) WriteWarning(wrr Warning) error {
+	var errs []error
+	for _, s := range c.stages {
+		next, err := s.fn(wrr)
+		if s.audit != nil {
+			audit(s.audit, s.layer, wrr, next, err)
+		}
+		if err != nil {
+			errs = append(errs, err)
+		}
+		if next == nil {
+			return joinErrors(errs)
+		}
+		wrr = next
+	}
+	if c.w != nil {
+		if err := c.w.WriteWarning(wrr); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return joinErrors(errs)
+}
+
+// joinErrors returns the only error as is, or all the errors joined.
+func joinErrors(errs []error) error {
+	if len(errs) == 1 {
+		return errs[0]
+	}
+	return errors.Join(errs...)
+}
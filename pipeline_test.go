@@ -0,0 +1,96 @@
+package warnings_test
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/runbed/warnings"
+)
+
+type discardWriter struct{}
+
+func (discardWriter) WriteWarning(warnings.Warning) error {
+	return nil
+}
+
+// layered returns a context with n helper layers on top of a discarding writer.
+func layered(n int) context.Context {
+	ctx := warnings.Attach(context.Background(), discardWriter{})
+	for i := 0; i < n; i++ {
+		switch i % 4 {
+		case 0:
+			ctx = warnings.Tap(ctx, func(wrr warnings.Warning) {})
+		case 1:
+			ctx = warnings.Filter(ctx, func(wrr warnings.Warning) bool { return true })
+		case 2:
+			ctx = warnings.Map(ctx, func(wrr warnings.Warning) warnings.Warning { return wrr })
+		case 3:
+			ctx = warnings.Attach(ctx, discardWriter{})
+		}
+	}
+	return ctx
+}
+
+func TestPipeline_Order(t *testing.T) {
+	w := &mockWriter{}
+	var got []string
+	ctx := warnings.Attach(context.Background(), w)
+	ctx = warnings.Tap(ctx, func(wrr warnings.Warning) { got = append(got, "outer") })
+	ctx = warnings.Map(ctx, func(wrr warnings.Warning) warnings.Warning { return warnings.New(wrr.Warn() + "!") })
+	ctx = warnings.Tap(ctx, func(wrr warnings.Warning) { got = append(got, "inner") })
+	ctx = warnings.Filter(ctx, func(wrr warnings.Warning) bool { return wrr.Warn() != "ignore" })
+	warnings.Warnf(ctx, "test")
+	warnings.Warnf(ctx, "ignore")
+	if len(got) != 2 || got[0] != "inner" || got[1] != "outer" {
+		t.Fatalf("expected [inner outer], got %v", got)
+	}
+	if len(w.buf) != 1 || w.buf[0].Warn() != "test!" {
+		t.Fatalf("expected [test!], got %v", w.buf)
+	}
+}
+
+func TestPipeline_Layers(t *testing.T) {
+	w := &mockWriter{}
+	ctx := warnings.Attach(context.Background(), w)
+	for i := 0; i < 100; i++ {
+		ctx = warnings.Map(ctx, func(wrr warnings.Warning) warnings.Warning {
+			return warnings.New(wrr.Warn() + "+")
+		})
+		ctx = warnings.Scope(ctx, strconv.Itoa(i%10))
+	}
+	warnings.Warnf(ctx, "test")
+	if len(w.buf) != 1 {
+		t.Fatalf("expected 1 warning, got %v", w.buf)
+	}
+	if got := len(w.buf[0].Warn()); got != len("test")+100 {
+		t.Fatalf("expected 100 maps applied, got %v", w.buf[0].Warn())
+	}
+}
+
+func BenchmarkWarn_Layers(b *testing.B) {
+	for _, n := range []int{1, 10, 100} {
+		b.Run(strconv.Itoa(n), func(b *testing.B) {
+			ctx := layered(n)
+			wrr := warnings.New("test-warning")
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				warnings.Warn(ctx, wrr)
+			}
+		})
+	}
+}
+
+func BenchmarkEnabled_Layers(b *testing.B) {
+	for _, n := range []int{1, 10, 100} {
+		b.Run(strconv.Itoa(n), func(b *testing.B) {
+			ctx := layered(n)
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				warnings.Enabled(ctx)
+			}
+		})
+	}
+}
@@ -0,0 +1,316 @@
+package warnings
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"regexp"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Action is what a [Policy] does with the warnings matching a [Rule].
+type Action int
+
+const (
+	// ActionDefault writes the first occurrence of a warning for each source location.
+	ActionDefault Action = iota
+	// ActionError treats the warning as an error, see [Strict].
+	ActionError
+	// ActionIgnore never writes the warning.
+	ActionIgnore
+	// ActionAlways always writes the warning.
+	ActionAlways
+	// ActionModule writes the first occurrence of a warning for each source package.
+	ActionModule
+	// ActionOnce writes only the first occurrence of a warning, regardless of its location.
+	ActionOnce
+)
+
+var actionNames = []string{
+	ActionDefault: "default",
+	ActionError:   "error",
+	ActionIgnore:  "ignore",
+	ActionAlways:  "always",
+	ActionModule:  "module",
+	ActionOnce:    "once",
+}
+
+// String returns the name of the action.
+func (a Action) String() string {
+	if a < 0 || int(a) >= len(actionNames) {
+		return "action(" + strconv.Itoa(int(a)) + ")"
+	}
+	return actionNames[a]
+}
+
+// ParseAction parses the name of an action.
+// As with Python warning filters, any prefix of a name is accepted and an empty name is [ActionDefault].
+func ParseAction(s string) (Action, error) {
+	for _, a := range []Action{ActionDefault, ActionAlways, ActionIgnore, ActionModule, ActionOnce, ActionError} {
+		if strings.HasPrefix(a.String(), s) {
+			return a, nil
+		}
+	}
+	return 0, fmt.Errorf("invalid warning action: %q", s)
+}
+
+// Rule is a warning filter of a [Policy], modeled after Python's warnings.filterwarnings.
+// A warning matches the rule when it matches all the non-empty fields.
+type Rule struct {
+	// Action is applied to the matching warnings.
+	Action Action
+	// Message is a regular expression that must match the start of the warning message, case-insensitively.
+	Message string
+	// Code is a pattern with the syntax of [path.Match] that must match the warning code, see [CodeOf].
+	Code string
+	// Category is a pattern with the syntax of [path.Match] that must match the warning category, see [CategoryOf].
+	Category string
+	// Module is a regular expression that must match the full path of the package that wrote the warning.
+	Module string
+}
+
+// ParseRule parses a rule from a string in the form action:message:code:category:module,
+// e.g. "ignore::W1001" or "error:deprecated.*:W2*". Trailing fields can be omitted.
+func ParseRule(spec string) (Rule, error) {
+	fields := strings.Split(spec, ":")
+	if len(fields) > 5 {
+		return Rule{}, fmt.Errorf("invalid warning rule %q: too many fields", spec)
+	}
+	fields = append(fields, make([]string, 5-len(fields))...)
+	for i := range fields {
+		fields[i] = strings.TrimSpace(fields[i])
+	}
+	action, err := ParseAction(fields[0])
+	if err != nil {
+		return Rule{}, fmt.Errorf("invalid warning rule %q: %w", spec, err)
+	}
+	r := Rule{
+		Action:   action,
+		Message:  fields[1],
+		Code:     fields[2],
+		Category: fields[3],
+		Module:   fields[4],
+	}
+	if _, err := r.compile(); err != nil {
+		return Rule{}, fmt.Errorf("invalid warning rule %q: %w", spec, err)
+	}
+	return r, nil
+}
+
+// String returns the rule in the form accepted by [ParseRule].
+func (r Rule) String() string {
+	s := strings.Join([]string{r.Action.String(), r.Message, r.Code, r.Category, r.Module}, ":")
+	return strings.TrimRight(s, ":")
+}
+
+type compiledRule struct {
+	Rule
+	message *regexp.Regexp
+	module  *regexp.Regexp
+}
+
+func (r Rule) compile() (compiledRule, error) {
+	cr := compiledRule{Rule: r}
+	var err error
+	if r.Message != "" {
+		if cr.message, err = regexp.Compile(`(?i)^(?:` + r.Message + `)`); err != nil {
+			return cr, err
+		}
+	}
+	if r.Module != "" {
+		if cr.module, err = regexp.Compile(`^(?:` + r.Module + `)$`); err != nil {
+			return cr, err
+		}
+	}
+	for _, pattern := range []string{r.Code, r.Category} {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return cr, err
+		}
+	}
+	return cr, nil
+}
+
+func (r *compiledRule) matches(wrr Warning, src *source) bool {
+	if r.message != nil && !r.message.MatchString(wrr.Warn()) {
+		return false
+	}
+	if r.Code != "" && !matchPattern(r.Code, CodeOf(wrr)) {
+		return false
+	}
+	if r.Category != "" && !matchPattern(r.Category, CategoryOf(wrr)) {
+		return false
+	}
+	return r.module == nil || r.module.MatchString(src.module())
+}
+
+func matchPattern(pattern, s string) bool {
+	ok, _ := path.Match(pattern, s)
+	return ok
+}
+
+// Policy is an ordered list of rules deciding what happens to warnings, see [WithPolicy].
+// The first matching rule applies. Warnings that match no rule get [ActionDefault].
+// A policy is not thread-safe. It must not be modified concurrently.
+type Policy struct {
+	rules []compiledRule
+}
+
+// NewPolicy returns a new Policy with the given rules.
+func NewPolicy(rules ...Rule) (*Policy, error) {
+	p := new(Policy)
+	if err := p.Add(rules...); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// Add appends rules to the policy. Rules added later have a lower priority.
+func (p *Policy) Add(rules ...Rule) error {
+	compiled := make([]compiledRule, 0, len(rules))
+	for _, r := range rules {
+		cr, err := r.compile()
+		if err != nil {
+			return fmt.Errorf("invalid warning rule %q: %w", r, err)
+		}
+		compiled = append(compiled, cr)
+	}
+	p.rules = append(p.rules, compiled...)
+	return nil
+}
+
+// Rules returns the rules of the policy.
+func (p *Policy) Rules() []Rule {
+	rules := make([]Rule, len(p.rules))
+	for i, r := range p.rules {
+		rules[i] = r.Rule
+	}
+	return rules
+}
+
+// WithPolicy returns a new context that applies the policy to the written warnings as a single layer.
+// Warnings with [ActionError] make [Warn] return a [StrictError], even when no writer is attached to the context.
+// The location that wrote a warning is recorded by [Warn], so that module rules and deduplication
+// still apply to the right location when the warning is staged or delivered later, e.g. by [Tx.Commit].
+// The policy is copied, later changes do not affect the returned context.
+func WithPolicy(ctx context.Context, p *Policy, opts ...LayerOption) context.Context {
+	pa := &policyApplier{
+		rules: append([]compiledRule(nil), p.rules...),
+		seen:  make(map[string]struct{}),
+	}
+	ctx = addStage(ctx, KindPolicy, opts, pa.apply)
+	pp := *getPipeline(ctx)
+	pp.origin.depth = max(pp.origin.depth, maxSourceDepth)
+	return setPipeline(ctx, pp)
+}
+
+type policyApplier struct {
+	rules []compiledRule
+	mtx   sync.Mutex
+	seen  map[string]struct{}
+}
+
+func (pa *policyApplier) apply(wrr Warning) (Warning, error) {
+	src := sourceOf(wrr)
+	action := ActionDefault
+	for i := range pa.rules {
+		if pa.rules[i].matches(wrr, src) {
+			action = pa.rules[i].Action
+			break
+		}
+	}
+	var key string
+	switch action {
+	case ActionIgnore:
+		return nil, nil
+	case ActionError:
+		return nil, &StrictError{wrr}
+	case ActionAlways:
+		return wrr, nil
+	case ActionOnce:
+		key = "once"
+	case ActionModule:
+		key = "module:" + src.module()
+	default:
+		key = "default:" + src.location()
+	}
+	key = strings.Join([]string{key, CategoryOf(wrr), CodeOf(wrr), wrr.Warn()}, "\x00")
+	if !pa.first(key) {
+		return nil, nil
+	}
+	return wrr, nil
+}
+
+// first reports whether the key is seen for the first time.
+func (pa *policyApplier) first(key string) bool {
+	pa.mtx.Lock()
+	defer pa.mtx.Unlock()
+	if _, ok := pa.seen[key]; ok {
+		return false
+	}
+	pa.seen[key] = struct{}{}
+	return true
+}
+
+// source lazily resolves the first caller outside of this package,
+// from the program counters recorded by [Warn], or from the current stack if there are none.
+type source struct {
+	pcs      []uintptr
+	frame    runtime.Frame
+	resolved bool
+}
+
+const pkgPrefix = "github.com/runbed/warnings."
+
+// maxSourceDepth is the number of program counters recorded to find the caller outside of this package.
+const maxSourceDepth = 16
+
+func sourceOf(wrr Warning) *source {
+	o, ok := find[*originWarning](wrr)
+	if !ok || o.pcs == nil {
+		return new(source)
+	}
+	return &source{pcs: o.pcs}
+}
+
+func (s *source) resolve() runtime.Frame {
+	if s.resolved {
+		return s.frame
+	}
+	s.resolved = true
+	pcs := s.pcs
+	if pcs == nil {
+		pcs = make([]uintptr, 64)
+		pcs = pcs[:runtime.Callers(2, pcs)]
+	}
+	frames := runtime.CallersFrames(pcs)
+	for {
+		frame, more := frames.Next()
+		if !strings.HasPrefix(frame.Function, pkgPrefix) {
+			s.frame = frame
+			break
+		}
+		if !more {
+			break
+		}
+	}
+	return s.frame
+}
+
+// module returns the package path of the caller.
+func (s *source) module() string {
+	fn := s.resolve().Function
+	slash := strings.LastIndexByte(fn, '/') + 1
+	if dot := strings.IndexByte(fn[slash:], '.'); dot >= 0 {
+		return fn[:slash+dot]
+	}
+	return fn
+}
+
+// location returns the file and line of the caller.
+func (s *source) location() string {
+	frame := s.resolve()
+	return frame.File + ":" + strconv.Itoa(frame.Line)
+}
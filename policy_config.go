@@ -0,0 +1,63 @@
+package warnings
+
+import (
+	"os"
+	"strings"
+)
+
+// EnvVar is the conventional name of the environment variable holding a warning policy, see [PolicyFromEnv].
+const EnvVar = "GOWARNINGS"
+
+// ParsePolicy parses a comma-separated list of rules, see [ParseRule].
+// As with Python's PYTHONWARNINGS, rules listed later take precedence over rules listed earlier.
+func ParsePolicy(spec string) (*Policy, error) {
+	p := new(Policy)
+	for _, rule := range strings.Split(spec, ",") {
+		if strings.TrimSpace(rule) == "" {
+			continue
+		}
+		if err := p.Set(rule); err != nil {
+			return nil, err
+		}
+	}
+	return p, nil
+}
+
+// PolicyFromEnv parses the policy held by the environment variable, see [ParsePolicy].
+// It returns an empty policy if the variable is not set.
+func PolicyFromEnv(name string) (*Policy, error) {
+	return ParsePolicy(os.Getenv(name))
+}
+
+// Set parses a rule and adds it to the policy with the highest priority.
+// Together with [Policy.String], it implements the [flag.Value] interface,
+// so a policy can be configured with repeatable flags, like Python's -W option:
+//
+//	policy, err := warnings.PolicyFromEnv(warnings.EnvVar)
+//	flag.Var(policy, "W", "warning rule, e.g. error::W2*")
+//	flag.Parse()
+//	ctx = warnings.WithPolicy(ctx, policy)
+func (p *Policy) Set(spec string) error {
+	r, err := ParseRule(spec)
+	if err != nil {
+		return err
+	}
+	cr, err := r.compile()
+	if err != nil {
+		return err
+	}
+	p.rules = append([]compiledRule{cr}, p.rules...)
+	return nil
+}
+
+// String returns the rules of the policy, in the form accepted by [ParsePolicy].
+func (p *Policy) String() string {
+	if p == nil {
+		return ""
+	}
+	specs := make([]string, len(p.rules))
+	for i, r := range p.rules {
+		specs[len(p.rules)-1-i] = r.String()
+	}
+	return strings.Join(specs, ",")
+}
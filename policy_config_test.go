@@ -0,0 +1,79 @@
+package warnings_test
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"io"
+	"testing"
+
+	"github.com/runbed/warnings"
+)
+
+func TestParsePolicy(t *testing.T) {
+	policy, err := warnings.ParsePolicy("error, ignore::W1001,")
+	if err != nil {
+		t.Fatalf("expected nil, got %v", err)
+	}
+	rules := policy.Rules()
+	if len(rules) != 2 {
+		t.Fatalf("expected 2 rules, got %v", rules)
+	}
+	if rules[0].Action != warnings.ActionIgnore || rules[1].Action != warnings.ActionError {
+		t.Fatalf("expected later rules to take precedence, got %v", rules)
+	}
+	if got := policy.String(); got != "error,ignore::W1001" {
+		t.Fatalf("expected error,ignore::W1001, got %v", got)
+	}
+	ctx := warnings.WithPolicy(context.Background(), policy)
+	if err := warnings.Warn(ctx, warnings.WithCode(warnings.New("test"), "W1001")); err != nil {
+		t.Fatalf("expected nil, got %v", err)
+	}
+	if err := warnings.Warnf(ctx, "test"); !errors.Is(err, warnings.ErrStrict) {
+		t.Fatalf("expected %v, got %v", warnings.ErrStrict, err)
+	}
+}
+
+func TestParsePolicy_Error(t *testing.T) {
+	if _, err := warnings.ParsePolicy("ignore,unknown"); err == nil {
+		t.Fatalf("expected error, got nil")
+	}
+}
+
+func TestPolicyFromEnv(t *testing.T) {
+	t.Setenv(warnings.EnvVar, "ignore,error::W2*")
+	policy, err := warnings.PolicyFromEnv(warnings.EnvVar)
+	if err != nil {
+		t.Fatalf("expected nil, got %v", err)
+	}
+	if got := policy.String(); got != "ignore,error::W2*" {
+		t.Fatalf("expected ignore,error::W2*, got %v", got)
+	}
+	t.Setenv(warnings.EnvVar, "")
+	policy, err = warnings.PolicyFromEnv(warnings.EnvVar)
+	if err != nil {
+		t.Fatalf("expected nil, got %v", err)
+	}
+	if rules := policy.Rules(); len(rules) != 0 {
+		t.Fatalf("expected no rules, got %v", rules)
+	}
+}
+
+func TestPolicy_Flag(t *testing.T) {
+	policy, err := warnings.ParsePolicy("ignore")
+	if err != nil {
+		t.Fatalf("expected nil, got %v", err)
+	}
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	fs.Var(policy, "W", "warning rule")
+	if err := fs.Parse([]string{"-W", "error::W2*", "-W", "always::W2001"}); err != nil {
+		t.Fatalf("expected nil, got %v", err)
+	}
+	if got := policy.String(); got != "ignore,error::W2*,always::W2001" {
+		t.Fatalf("expected ignore,error::W2*,always::W2001, got %v", got)
+	}
+	if err := fs.Parse([]string{"-W", "unknown"}); err == nil {
+		t.Fatalf("expected error, got nil")
+	}
+}
@@ -0,0 +1,201 @@
+package warnings_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/runbed/warnings"
+)
+
+// ExampleWithPolicy demonstrates how to use a policy to filter warnings with declarative rules.
+func ExampleWithPolicy() {
+	// create a new collector
+	collector := warnings.NewCollector()
+	defer collector.Close() // make sure to close the collector when done
+	// attach the collector to a context
+	ctx := warnings.Attach(context.Background(), collector)
+	// parse the rules and install the policy
+	ignore, _ := warnings.ParseRule("ignore::W1001")
+	strict, _ := warnings.ParseRule("error:deprecated.*:W2*")
+	policy, _ := warnings.NewPolicy(ignore, strict)
+	ctx = warnings.WithPolicy(ctx, policy)
+	// use Warn or Warnf to write warnings to the context
+	fmt.Println(warnings.Warn(ctx, warnings.WithCode(warnings.New("this is ignored"), "W1001")))
+	fmt.Println(warnings.Warn(ctx, warnings.WithCode(warnings.New("deprecated option"), "W2001")))
+	for i := 0; i < 3; i++ {
+		warnings.Warnf(ctx, "this is a warning")
+	}
+	// read all warnings from the collector
+	wrrs, err := warnings.ReadAll(collector)
+	if err != nil {
+		// handle error
+	}
+	for _, wrr := range wrrs {
+		fmt.Println(wrr.Warn())
+	}
+	// Output:
+	// <nil>
+	// warning treated as error: deprecated option
+	// this is a warning
+}
+
+func TestParseRule(t *testing.T) {
+	tests := []struct {
+		spec string
+		want warnings.Rule
+	}{
+		{"", warnings.Rule{Action: warnings.ActionDefault}},
+		{"ignore", warnings.Rule{Action: warnings.ActionIgnore}},
+		{"i", warnings.Rule{Action: warnings.ActionIgnore}},
+		{"ignore::W1001", warnings.Rule{Action: warnings.ActionIgnore, Code: "W1001"}},
+		{"error:deprecated.*:W2*", warnings.Rule{Action: warnings.ActionError, Message: "deprecated.*", Code: "W2*"}},
+		{" once : msg : W1 : deprecation : main ", warnings.Rule{
+			Action:   warnings.ActionOnce,
+			Message:  "msg",
+			Code:     "W1",
+			Category: "deprecation",
+			Module:   "main",
+		}},
+	}
+	for _, tt := range tests {
+		got, err := warnings.ParseRule(tt.spec)
+		if err != nil {
+			t.Errorf("%q: expected nil, got %v", tt.spec, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("%q: expected %+v, got %+v", tt.spec, tt.want, got)
+		}
+	}
+}
+
+func TestParseRule_Errors(t *testing.T) {
+	for _, spec := range []string{
+		"unknown",
+		"ignore:(:",
+		"ignore:::[:",
+		"ignore::::(",
+		"ignore::::main:10",
+	} {
+		if _, err := warnings.ParseRule(spec); err == nil {
+			t.Errorf("%q: expected error, got nil", spec)
+		}
+	}
+}
+
+func TestRule_String(t *testing.T) {
+	for _, spec := range []string{"default", "ignore::W1001", "error:deprecated.*:W2*", "once:::deprecation:main"} {
+		r, err := warnings.ParseRule(spec)
+		if err != nil {
+			t.Fatalf("expected nil, got %v", err)
+		}
+		if got := r.String(); got != spec {
+			t.Errorf("expected %v, got %v", spec, got)
+		}
+	}
+}
+
+func TestWithPolicy(t *testing.T) {
+	policy, err := warnings.NewPolicy(
+		warnings.Rule{Action: warnings.ActionIgnore, Category: "noise"},
+		warnings.Rule{Action: warnings.ActionError, Message: "fatal"},
+		warnings.Rule{Action: warnings.ActionAlways, Message: "always"},
+		warnings.Rule{Action: warnings.ActionOnce, Message: "once"},
+	)
+	if err != nil {
+		t.Fatalf("expected nil, got %v", err)
+	}
+	w := &mockWriter{}
+	ctx := warnings.Attach(context.Background(), w)
+	ctx = warnings.WithPolicy(ctx, policy)
+	warnings.Warn(ctx, warnings.WithCategory(warnings.New("always"), "noise"))
+	if err := warnings.Warnf(ctx, "FATAL: test"); !errors.Is(err, warnings.ErrStrict) {
+		t.Fatalf("expected %v, got %v", warnings.ErrStrict, err)
+	}
+	for i := 0; i < 2; i++ {
+		warnings.Warnf(ctx, "always")
+		warnings.Warnf(ctx, "once")
+	}
+	warnings.Warnf(ctx, "once")
+	var got []string
+	for _, wrr := range w.buf {
+		got = append(got, wrr.Warn())
+	}
+	if fmt.Sprint(got) != "[always once always]" {
+		t.Fatalf("expected [always once always], got %v", got)
+	}
+}
+
+func TestWithPolicy_Default(t *testing.T) {
+	policy, err := warnings.NewPolicy()
+	if err != nil {
+		t.Fatalf("expected nil, got %v", err)
+	}
+	w := &mockWriter{}
+	ctx := warnings.Attach(context.Background(), w)
+	ctx = warnings.WithPolicy(ctx, policy)
+	for i := 0; i < 3; i++ {
+		warnings.Warnf(ctx, "test")
+	}
+	warnings.Warnf(ctx, "test")
+	if len(w.buf) != 2 {
+		t.Fatalf("expected 2 warnings, one for each location, got %v", w.buf)
+	}
+}
+
+func TestWithPolicy_Module(t *testing.T) {
+	policy, err := warnings.NewPolicy(
+		warnings.Rule{Action: warnings.ActionModule, Module: "github.com/runbed/warnings_test"},
+		warnings.Rule{Action: warnings.ActionIgnore},
+	)
+	if err != nil {
+		t.Fatalf("expected nil, got %v", err)
+	}
+	w := &mockWriter{}
+	ctx := warnings.Attach(context.Background(), w)
+	ctx = warnings.WithPolicy(ctx, policy)
+	warnings.Warnf(ctx, "test")
+	warnings.Warnf(ctx, "test")
+	if len(w.buf) != 1 {
+		t.Fatalf("expected 1 warning, got %v", w.buf)
+	}
+}
+
+func TestWithPolicyNoWriter(t *testing.T) {
+	policy, err := warnings.NewPolicy(warnings.Rule{Action: warnings.ActionError})
+	if err != nil {
+		t.Fatalf("expected nil, got %v", err)
+	}
+	ctx := warnings.WithPolicy(context.Background(), policy)
+	if err := warnings.Warnf(ctx, "test"); !errors.Is(err, warnings.ErrStrict) {
+		t.Fatalf("expected %v, got %v", warnings.ErrStrict, err)
+	}
+}
+
+func TestNewPolicy_Error(t *testing.T) {
+	if _, err := warnings.NewPolicy(warnings.Rule{Message: "("}); err == nil {
+		t.Fatalf("expected error, got nil")
+	}
+}
+
+func TestWithPolicy_Begin(t *testing.T) {
+	policy, err := warnings.NewPolicy()
+	if err != nil {
+		t.Fatalf("expected nil, got %v", err)
+	}
+	w := &mockWriter{}
+	ctx := warnings.Attach(context.Background(), w)
+	ctx = warnings.WithPolicy(ctx, policy)
+	txCtx, tx := warnings.Begin(ctx)
+	// the warnings are deduplicated by the location that wrote them, not by the caller of Commit
+	warnings.Warnf(txCtx, "test")
+	warnings.Warnf(txCtx, "test")
+	if err := tx.Commit(); err != nil {
+		t.Fatalf("expected nil, got %v", err)
+	}
+	if len(w.buf) != 2 {
+		t.Fatalf("expected 2 warnings, one for each location, got %v", w.buf)
+	}
+}
@@ -0,0 +1,78 @@
+package warnings
+
+import (
+	"io"
+	"sort"
+	"sync"
+)
+
+// PriorityCollector is a collector that reads the most important warnings first:
+// by descending severity, see [SeverityOf], then in the order they were written.
+// When its capacity is reached, the least important warning is evicted, i.e. the newest one with the lowest severity,
+// so that a truncated report shows the most important warnings.
+// It implements the [Reader], [Writer] and [io.Closer] interfaces.
+// Read operations are non-blocking and return [io.EOF] when there are no more warnings in the buffer.
+// The priority collector is thread-safe.
+type PriorityCollector struct {
+	capacity int
+	buf      []Warning // sorted by descending severity, then by arrival
+	mtx      sync.Mutex
+	closed   bool
+}
+
+// NewPriorityCollector returns a new PriorityCollector holding at most capacity warnings.
+// Zero means unbounded.
+func NewPriorityCollector(capacity int) *PriorityCollector {
+	return &PriorityCollector{capacity: capacity}
+}
+
+// Close closes the collector.
+func (c *PriorityCollector) Close() error {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	if c.closed {
+		return ErrClosed
+	}
+	c.closed = true
+	c.buf = nil
+	return nil
+}
+
+// WriteWarning writes a warning to the collector.
+// If the collector is full and the warning is the least important one, it is discarded and [ErrQueueFull] is returned.
+func (c *PriorityCollector) WriteWarning(wrr Warning) error {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	if c.closed {
+		return ErrClosed
+	}
+	severity := SeverityOf(wrr)
+	i := sort.Search(len(c.buf), func(i int) bool {
+		return SeverityOf(c.buf[i]) < severity
+	})
+	if c.capacity > 0 && len(c.buf) >= c.capacity {
+		if i == len(c.buf) {
+			return ErrQueueFull
+		}
+		c.buf = c.buf[:len(c.buf)-1]
+	}
+	c.buf = append(c.buf, nil)
+	copy(c.buf[i+1:], c.buf[i:])
+	c.buf[i] = wrr
+	return nil
+}
+
+// ReadWarning reads the most important warning from the collector.
+func (c *PriorityCollector) ReadWarning() (Warning, error) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	if c.closed {
+		return nil, ErrClosed
+	}
+	if len(c.buf) == 0 {
+		return nil, io.EOF
+	}
+	w := c.buf[0]
+	c.buf = c.buf[1:]
+	return w, nil
+}
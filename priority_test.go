@@ -0,0 +1,80 @@
+package warnings_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/runbed/warnings"
+)
+
+// ExamplePriorityCollector demonstrates how to keep the most important warnings of a truncated report.
+func ExamplePriorityCollector() {
+	// create a new priority collector keeping at most 2 warnings
+	collector := warnings.NewPriorityCollector(2)
+	defer collector.Close() // make sure to close the collector when done
+	// attach the collector to a context
+	ctx := warnings.Attach(context.Background(), collector)
+	warnings.Warn(ctx, warnings.WithSeverity(warnings.New("low"), warnings.SeverityLow))
+	warnings.Warn(ctx, warnings.WithSeverity(warnings.New("critical"), warnings.SeverityCritical))
+	warnings.Warnf(ctx, "medium")
+	// read all warnings from the collector, most important first
+	wrrs, err := warnings.ReadAll(collector)
+	if err != nil {
+		// handle error
+	}
+	for _, wrr := range wrrs {
+		fmt.Println(wrr.Warn())
+	}
+	// Output:
+	// critical
+	// medium
+}
+
+func severityWarning(msg string, s warnings.Severity) warnings.Warning {
+	return warnings.WithSeverity(warnings.New(msg), s)
+}
+
+func TestPriorityCollector(t *testing.T) {
+	c := warnings.NewPriorityCollector(0)
+	c.WriteWarning(severityWarning("low-1", warnings.SeverityLow))
+	c.WriteWarning(warnings.New("medium-1"))
+	c.WriteWarning(severityWarning("high-1", warnings.SeverityHigh))
+	c.WriteWarning(severityWarning("low-2", warnings.SeverityLow))
+	c.WriteWarning(severityWarning("high-2", warnings.SeverityHigh))
+	if got, want := readAllStrings(t, c), "[high-1 high-2 medium-1 low-1 low-2]"; got != want {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	if err := c.Close(); err != nil {
+		t.Fatalf("expected nil, got %v", err)
+	}
+	if _, err := c.ReadWarning(); err != warnings.ErrClosed {
+		t.Fatalf("expected %v, got %v", warnings.ErrClosed, err)
+	}
+	if err := c.WriteWarning(warnings.New("test")); err != warnings.ErrClosed {
+		t.Fatalf("expected %v, got %v", warnings.ErrClosed, err)
+	}
+	if err := c.Close(); err != warnings.ErrClosed {
+		t.Fatalf("expected %v, got %v", warnings.ErrClosed, err)
+	}
+}
+
+func TestPriorityCollector_Capacity(t *testing.T) {
+	c := warnings.NewPriorityCollector(3)
+	c.WriteWarning(severityWarning("low-1", warnings.SeverityLow))
+	c.WriteWarning(severityWarning("low-2", warnings.SeverityLow))
+	c.WriteWarning(severityWarning("high-1", warnings.SeverityHigh))
+	if err := c.WriteWarning(severityWarning("low-3", warnings.SeverityLow)); err != warnings.ErrQueueFull {
+		t.Fatalf("expected %v, got %v", warnings.ErrQueueFull, err)
+	}
+	if err := c.WriteWarning(severityWarning("critical-1", warnings.SeverityCritical)); err != nil {
+		t.Fatalf("expected nil, got %v", err)
+	}
+	if got, want := readAllStrings(t, c), "[critical-1 high-1 low-1]"; got != want {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	if _, err := c.ReadWarning(); err != io.EOF {
+		t.Fatalf("expected %v, got %v", io.EOF, err)
+	}
+}
@@ -13,6 +13,14 @@ type Reader interface {
 	ReadWarning() (Warning, error)
 }
 
+// ReaderFunc is an adapter to allow the use of ordinary functions as a [Reader].
+type ReaderFunc func() (Warning, error)
+
+// ReadWarning calls f().
+func (f ReaderFunc) ReadWarning() (Warning, error) {
+	return f()
+}
+
 // ReadAll reads all the warnings from the reader.
 // It stops reading when it encounters an error or [io.EOF].
 func ReadAll(r Reader) ([]Warning, error) {
@@ -28,3 +36,136 @@ func ReadAll(r Reader) ([]Warning, error) {
 	}
 	return result, nil
 }
+
+type mergeReader struct {
+	readers []Reader
+	cmp     func(a, b Warning) int
+	heads   []Warning // next warning of each reader, compared with cmp
+	next    int       // next reader in round-robin order
+}
+
+// MergeReaders returns a reader that interleaves the warnings of the provided readers.
+// If cmp is nil, the readers are read in round-robin order.
+// Otherwise, it returns the smallest of the next warnings of the readers according to cmp,
+// e.g. [CompareStamps] merges stamped warnings in the order they were written.
+// A reader returning [io.EOF] is skipped for that read, the merged reader returns [io.EOF]
+// when all the readers do. Any other error is returned immediately.
+func MergeReaders(cmp func(a, b Warning) int, readers ...Reader) Reader {
+	return &mergeReader{
+		readers: readers,
+		cmp:     cmp,
+		heads:   make([]Warning, len(readers)),
+	}
+}
+
+func (m *mergeReader) ReadWarning() (Warning, error) {
+	if m.cmp == nil {
+		return m.readRoundRobin()
+	}
+	best := -1
+	for i, r := range m.readers {
+		if m.heads[i] == nil {
+			wrr, err := r.ReadWarning()
+			if errors.Is(err, io.EOF) {
+				continue
+			} else if err != nil {
+				return nil, err
+			}
+			m.heads[i] = wrr
+		}
+		if best < 0 || m.cmp(m.heads[i], m.heads[best]) < 0 {
+			best = i
+		}
+	}
+	if best < 0 {
+		return nil, io.EOF
+	}
+	wrr := m.heads[best]
+	m.heads[best] = nil
+	return wrr, nil
+}
+
+func (m *mergeReader) readRoundRobin() (Warning, error) {
+	for range m.readers {
+		i := m.next
+		m.next = (m.next + 1) % len(m.readers)
+		wrr, err := m.readers[i].ReadWarning()
+		if errors.Is(err, io.EOF) {
+			continue
+		}
+		return wrr, err
+	}
+	return nil, io.EOF
+}
+
+type concatReader struct {
+	readers []Reader
+}
+
+// ConcatReaders returns a reader that is the logical concatenation of the provided readers, like [io.MultiReader].
+// The readers are read sequentially, a reader is not read anymore once it returns [io.EOF].
+// Any other error is returned immediately.
+func ConcatReaders(readers ...Reader) Reader {
+	return &concatReader{append([]Reader(nil), readers...)}
+}
+
+func (c *concatReader) ReadWarning() (Warning, error) {
+	for len(c.readers) > 0 {
+		wrr, err := c.readers[0].ReadWarning()
+		if errors.Is(err, io.EOF) {
+			c.readers = c.readers[1:]
+			continue
+		}
+		return wrr, err
+	}
+	return nil, io.EOF
+}
+
+// FilterReader returns a reader that skips the warnings of r for which fn returns false.
+func FilterReader(r Reader, fn func(wrr Warning) bool) Reader {
+	return ReaderFunc(func() (Warning, error) {
+		for {
+			wrr, err := r.ReadWarning()
+			if err != nil || fn(wrr) {
+				return wrr, err
+			}
+		}
+	})
+}
+
+// MapReader returns a reader that transforms the warnings of r using the provided function.
+func MapReader(r Reader, fn func(wrr Warning) Warning) Reader {
+	return ReaderFunc(func() (Warning, error) {
+		wrr, err := r.ReadWarning()
+		if err != nil {
+			return nil, err
+		}
+		return fn(wrr), nil
+	})
+}
+
+// LimitReader returns a reader that reads at most n warnings from r, then returns [io.EOF].
+func LimitReader(r Reader, n int) Reader {
+	return ReaderFunc(func() (Warning, error) {
+		if n <= 0 {
+			return nil, io.EOF
+		}
+		wrr, err := r.ReadWarning()
+		if err == nil {
+			n--
+		}
+		return wrr, err
+	})
+}
+
+// TeeReader returns a reader that writes to w the warnings it reads from r, like [io.TeeReader].
+// If writing fails, the warning is returned along with the write error.
+func TeeReader(r Reader, w Writer) Reader {
+	return ReaderFunc(func() (Warning, error) {
+		wrr, err := r.ReadWarning()
+		if err != nil {
+			return nil, err
+		}
+		return wrr, w.WriteWarning(wrr)
+	})
+}
@@ -1,8 +1,10 @@
 package warnings_test
 
 import (
+	"context"
 	"fmt"
 	"io"
+	"strings"
 	"testing"
 
 	"github.com/runbed/warnings"
@@ -84,3 +86,164 @@ func TestReadAll_Empty(t *testing.T) {
 		t.Fatalf("expected no warnings, got %v", l)
 	}
 }
+
+func TestReaderFunc(t *testing.T) {
+	want := []warnings.Warning{warnings.New("test-1"), warnings.New("test-2")}
+	i := 0
+	r := warnings.ReaderFunc(func() (warnings.Warning, error) {
+		if i >= len(want) {
+			return nil, io.EOF
+		}
+		i++
+		return want[i-1], nil
+	})
+	wrrs, err := warnings.ReadAll(r)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(wrrs) != 2 || wrrs[0] != want[0] || wrrs[1] != want[1] {
+		t.Fatalf("expected %v, got %v", want, wrrs)
+	}
+}
+
+// collectorOf returns a collector holding warnings with the provided messages.
+func collectorOf(msgs ...string) *warnings.Collector {
+	c := warnings.NewCollector()
+	for _, msg := range msgs {
+		c.WriteWarning(warnings.New(msg))
+	}
+	return c
+}
+
+func readAllStrings(t *testing.T, r warnings.Reader) string {
+	t.Helper()
+	wrrs, err := warnings.ReadAll(r)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	var got []string
+	for _, wrr := range wrrs {
+		got = append(got, wrr.Warn())
+	}
+	return fmt.Sprint(got)
+}
+
+// ExampleMergeReaders demonstrates how to merge the warnings of several collectors in the order they were written.
+func ExampleMergeReaders() {
+	// create two collectors
+	c1, c2 := warnings.NewCollector(), warnings.NewCollector()
+	defer c1.Close()
+	defer c2.Close()
+	// stamp the warnings written to both collectors
+	ctx1 := warnings.Stamp(warnings.Attach(context.Background(), c1), nil)
+	ctx2 := warnings.Stamp(warnings.Attach(context.Background(), c2), nil)
+	warnings.Warnf(ctx1, "first")
+	warnings.Warnf(ctx2, "second")
+	warnings.Warnf(ctx1, "third")
+	// read the warnings of both collectors in order, skipping the second one
+	r := warnings.MergeReaders(warnings.CompareStamps, c1, c2)
+	r = warnings.FilterReader(r, func(wrr warnings.Warning) bool {
+		return wrr.Warn() != "second"
+	})
+	wrrs, err := warnings.ReadAll(r)
+	if err != nil {
+		// handle error
+	}
+	for _, wrr := range wrrs {
+		fmt.Println(wrr.Warn())
+	}
+	// Output:
+	// first
+	// third
+}
+
+func TestMergeReaders_RoundRobin(t *testing.T) {
+	r := warnings.MergeReaders(nil, collectorOf("a-1", "a-2", "a-3"), collectorOf(), collectorOf("b-1"))
+	if got, want := readAllStrings(t, r), "[a-1 b-1 a-2 a-3]"; got != want {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+}
+
+func TestMergeReaders_Compare(t *testing.T) {
+	r := warnings.MergeReaders(func(a, b warnings.Warning) int {
+		return strings.Compare(a.Warn(), b.Warn())
+	}, collectorOf("a", "d", "e"), collectorOf("b", "c", "f"))
+	if got, want := readAllStrings(t, r), "[a b c d e f]"; got != want {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+}
+
+func TestMergeReaders_Resume(t *testing.T) {
+	c := warnings.NewCollector()
+	r := warnings.MergeReaders(warnings.CompareStamps, c)
+	if _, err := r.ReadWarning(); err != io.EOF {
+		t.Fatalf("expected %v, got %v", io.EOF, err)
+	}
+	c.WriteWarning(warnings.New("test"))
+	if got, want := readAllStrings(t, r), "[test]"; got != want {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+}
+
+func TestMergeReaders_Error(t *testing.T) {
+	wantErr := fmt.Errorf("test-error")
+	r := warnings.MergeReaders(nil, &mockReader{[]mockReaderResult{{nil, wantErr}}})
+	if _, err := r.ReadWarning(); err != wantErr {
+		t.Fatalf("expected %v, got %v", wantErr, err)
+	}
+}
+
+func TestConcatReaders(t *testing.T) {
+	c1 := collectorOf("a-1", "a-2")
+	r := warnings.ConcatReaders(c1, collectorOf(), collectorOf("b-1"))
+	if got, want := readAllStrings(t, r), "[a-1 a-2 b-1]"; got != want {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	c1.WriteWarning(warnings.New("a-3"))
+	if _, err := r.ReadWarning(); err != io.EOF {
+		t.Fatalf("expected %v, got %v", io.EOF, err)
+	}
+}
+
+func TestFilterReader(t *testing.T) {
+	r := warnings.FilterReader(collectorOf("a", "ignore", "b", "ignore"), func(wrr warnings.Warning) bool {
+		return wrr.Warn() != "ignore"
+	})
+	if got, want := readAllStrings(t, r), "[a b]"; got != want {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+}
+
+func TestMapReader(t *testing.T) {
+	r := warnings.MapReader(collectorOf("a", "b"), func(wrr warnings.Warning) warnings.Warning {
+		return warnings.New(strings.ToUpper(wrr.Warn()))
+	})
+	if got, want := readAllStrings(t, r), "[A B]"; got != want {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+}
+
+func TestLimitReader(t *testing.T) {
+	c := collectorOf("a", "b", "c")
+	if got, want := readAllStrings(t, warnings.LimitReader(c, 2)), "[a b]"; got != want {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	if got, want := readAllStrings(t, c), "[c]"; got != want {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+}
+
+func TestTeeReader(t *testing.T) {
+	w := &mockWriter{}
+	if got, want := readAllStrings(t, warnings.TeeReader(collectorOf("a", "b"), w)), "[a b]"; got != want {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	if len(w.buf) != 2 {
+		t.Fatalf("expected 2 warnings, got %v", w.buf)
+	}
+	wantErr := fmt.Errorf("test-error")
+	r := warnings.TeeReader(collectorOf("a"), &mockWriter{result: wantErr})
+	if wrr, err := r.ReadWarning(); err != wantErr || wrr == nil {
+		t.Fatalf("expected the warning and %v, got %v and %v", wantErr, wrr, err)
+	}
+}
@@ -0,0 +1,90 @@
+package warnings
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// AttemptKey is the key of the attribute holding the attempt number of the warnings written by [Retry].
+const AttemptKey = "attempt"
+
+// RetryMode defines what happens to the warnings of the failed attempts of [Retry].
+type RetryMode int
+
+const (
+	// DiscardFailed discards the warnings of failed attempts.
+	DiscardFailed RetryMode = iota
+	// KeepFailed keeps the warnings of failed attempts.
+	KeepFailed
+	// SummarizeFailed replaces the warnings of each failed attempt with a single summary warning.
+	SummarizeFailed
+)
+
+// RetryPolicy configures [Retry].
+type RetryPolicy struct {
+	// Attempts is the maximum number of attempts. Values lower than 1 mean a single attempt.
+	Attempts int
+	// Delay returns how long to wait before the given attempt, starting with attempt 2.
+	// If nil, attempts are not delayed.
+	Delay func(attempt int) time.Duration
+	// Failed defines what happens to the warnings of failed attempts.
+	Failed RetryMode
+}
+
+// Retry calls fn until it succeeds or the maximum number of attempts is reached.
+// Each attempt runs under a context that tags warnings with the attempt number, see [AttemptKey] and [AttrsOf].
+// The warnings of each attempt but the last are staged in a transaction, see [Begin],
+// and are committed if the attempt succeeds or handled by the policy if it fails.
+// The last attempt writes its warnings to the context directly.
+// It returns the error of the last attempt, or the context error if the context is done while waiting.
+func Retry(ctx context.Context, policy RetryPolicy, fn func(ctx context.Context) error) error {
+	attempts := max(policy.Attempts, 1)
+	for attempt := 1; ; attempt++ {
+		if attempt > 1 && policy.Delay != nil {
+			if err := sleep(ctx, policy.Delay(attempt)); err != nil {
+				return err
+			}
+		}
+		attemptCtx := WithAttrs(ctx, slog.Int(AttemptKey, attempt))
+		if attempt == attempts {
+			return fn(attemptCtx)
+		}
+		txCtx, tx := Begin(attemptCtx)
+		err := fn(txCtx)
+		if err == nil {
+			return tx.Commit()
+		}
+		switch policy.Failed {
+		case KeepFailed:
+			_ = tx.Commit()
+		case SummarizeFailed:
+			if n, _ := tx.discard(); n > 0 {
+				_ = Warnf(attemptCtx, "%d warning(s) discarded from failed attempt %d: %v", n, attempt, err)
+			}
+		default:
+			_ = tx.Rollback()
+		}
+	}
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// AttemptOf returns the number of the current attempt of [Retry], or 0 if the context is not an attempt context.
+func AttemptOf(ctx context.Context) int {
+	for _, attr := range getPipeline(ctx).attrs {
+		if attr.Key == AttemptKey && attr.Value.Kind() == slog.KindInt64 {
+			return int(attr.Value.Int64())
+		}
+	}
+	return 0
+}
@@ -0,0 +1,162 @@
+package warnings_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/runbed/warnings"
+)
+
+// ExampleRetry demonstrates how to use the Retry function to retry an operation without the noise of failed attempts.
+func ExampleRetry() {
+	// create a new collector
+	collector := warnings.NewCollector()
+	defer collector.Close() // make sure to close the collector when done
+	// attach the collector to a context
+	ctx := warnings.Attach(context.Background(), collector)
+	// retry an operation that fails twice
+	err := warnings.Retry(ctx, warnings.RetryPolicy{
+		Attempts: 3,
+		Failed:   warnings.SummarizeFailed,
+	}, func(ctx context.Context) error {
+		attempt := warnings.AttemptOf(ctx)
+		warnings.Warnf(ctx, "this is a warning from attempt %d", attempt)
+		if attempt < 3 {
+			return fmt.Errorf("attempt %d failed", attempt)
+		}
+		return nil
+	})
+	if err != nil {
+		// handle error
+	}
+	// read all warnings from the collector
+	wrrs, err := warnings.ReadAll(collector)
+	if err != nil {
+		// handle error
+	}
+	for _, wrr := range wrrs {
+		fmt.Println(wrr.Warn())
+	}
+	// Output:
+	// 1 warning(s) discarded from failed attempt 1: attempt 1 failed
+	// 1 warning(s) discarded from failed attempt 2: attempt 2 failed
+	// this is a warning from attempt 3
+}
+
+func attemptOf(wrr warnings.Warning) int64 {
+	for _, attr := range warnings.AttrsOf(wrr) {
+		if attr.Key == warnings.AttemptKey {
+			return attr.Value.Int64()
+		}
+	}
+	return 0
+}
+
+func failing(n int) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		warnings.Warnf(ctx, "test-%d", warnings.AttemptOf(ctx))
+		if warnings.AttemptOf(ctx) <= n {
+			return fmt.Errorf("test-error")
+		}
+		return nil
+	}
+}
+
+func TestRetry(t *testing.T) {
+	tests := []struct {
+		mode warnings.RetryMode
+		want []string
+	}{
+		{warnings.DiscardFailed, []string{"test-3"}},
+		{warnings.KeepFailed, []string{"test-1", "test-2", "test-3"}},
+		{warnings.SummarizeFailed, []string{
+			"1 warning(s) discarded from failed attempt 1: test-error",
+			"1 warning(s) discarded from failed attempt 2: test-error",
+			"test-3",
+		}},
+	}
+	for _, tt := range tests {
+		w := &mockWriter{}
+		ctx := warnings.Attach(context.Background(), w)
+		err := warnings.Retry(ctx, warnings.RetryPolicy{Attempts: 5, Failed: tt.mode}, failing(2))
+		if err != nil {
+			t.Fatalf("expected nil, got %v", err)
+		}
+		if len(w.buf) != len(tt.want) {
+			t.Fatalf("expected %v, got %v", tt.want, w.buf)
+		}
+		for i, want := range tt.want {
+			if got := w.buf[i].Warn(); got != want {
+				t.Errorf("expected %v, got %v", want, got)
+			}
+		}
+		if got := attemptOf(w.buf[len(w.buf)-1]); got != 3 {
+			t.Errorf("expected attempt 3, got %v", got)
+		}
+	}
+}
+
+func TestRetry_LastAttempt(t *testing.T) {
+	w := &mockWriter{}
+	ctx := warnings.Attach(context.Background(), w)
+	err := warnings.Retry(ctx, warnings.RetryPolicy{Attempts: 2}, failing(5))
+	if err == nil || err.Error() != "test-error" {
+		t.Fatalf("expected test-error, got %v", err)
+	}
+	if len(w.buf) != 1 || w.buf[0].Warn() != "test-2" {
+		t.Fatalf("expected [test-2], got %v", w.buf)
+	}
+}
+
+func TestRetry_Delay(t *testing.T) {
+	var delays []int
+	err := warnings.Retry(context.Background(), warnings.RetryPolicy{
+		Attempts: 3,
+		Delay: func(attempt int) time.Duration {
+			delays = append(delays, attempt)
+			return time.Millisecond
+		},
+	}, failing(5))
+	if err == nil {
+		t.Fatalf("expected error, got nil")
+	}
+	if fmt.Sprint(delays) != "[2 3]" {
+		t.Fatalf("expected [2 3], got %v", delays)
+	}
+}
+
+func TestRetry_Canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	calls := 0
+	err := warnings.Retry(ctx, warnings.RetryPolicy{
+		Attempts: 3,
+		Delay: func(attempt int) time.Duration {
+			cancel()
+			return time.Hour
+		},
+	}, func(ctx context.Context) error {
+		calls++
+		return fmt.Errorf("test-error")
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected %v, got %v", context.Canceled, err)
+	}
+	if calls != 1 {
+		t.Fatalf("expected 1 call, got %v", calls)
+	}
+}
+
+func TestAttemptOf(t *testing.T) {
+	ctx := context.Background()
+	if got := warnings.AttemptOf(ctx); got != 0 {
+		t.Fatalf("expected 0, got %v", got)
+	}
+	ctx = warnings.WithAttrs(ctx, slog.Int(warnings.AttemptKey, 2))
+	if got := warnings.AttemptOf(ctx); got != 2 {
+		t.Fatalf("expected 2, got %v", got)
+	}
+}
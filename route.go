@@ -0,0 +1,99 @@
+package warnings
+
+import "context"
+
+// RouteRule selects the writer a [Router] dispatches a warning to, see [When] and [ByKey].
+type RouteRule struct {
+	dest func(wrr Warning) Writer
+}
+
+// When returns a rule that dispatches the warnings for which match returns true to w.
+// Use [Selector.Matches] to route by severity or code.
+func When(match func(wrr Warning) bool, w Writer) RouteRule {
+	return RouteRule{func(wrr Warning) Writer {
+		if match(wrr) {
+			return w
+		}
+		return nil
+	}}
+}
+
+// ByKey returns a rule that dispatches each warning to the writer registered for its key, e.g. [CategoryOf].
+// Warnings whose key has no writer do not match the rule. The map must not be modified afterwards.
+func ByKey(key func(wrr Warning) string, writers map[string]Writer) RouteRule {
+	return RouteRule{func(wrr Warning) Writer {
+		return writers[key(wrr)]
+	}}
+}
+
+// Router is a [Writer] that dispatches warnings to different writers.
+// Unlike the writer returned by [NewMultiWriter], which duplicates warnings to every writer,
+// it writes each warning to the first matching route, or to all of them if All is set.
+type Router struct {
+	// Routes are tried in order.
+	Routes []RouteRule
+	// All dispatches warnings to all the matching routes instead of the first one.
+	All bool
+	// Default receives the warnings matching no route. If nil, they are dropped.
+	Default Writer
+}
+
+// WriteWarning dispatches a warning. If any of the writers fail, the errors are returned as one error.
+func (r *Router) WriteWarning(wrr Warning) error {
+	var errs []error
+	matched := false
+	for _, route := range r.Routes {
+		w := route.dest(wrr)
+		if w == nil {
+			continue
+		}
+		matched = true
+		if err := w.WriteWarning(wrr); err != nil {
+			errs = append(errs, err)
+		}
+		if !r.All {
+			break
+		}
+	}
+	if !matched && r.Default != nil {
+		return r.Default.WriteWarning(wrr)
+	}
+	return joinErrors(errs)
+}
+
+// Route returns a new context that dispatches each warning to the first matching route.
+// The warnings matching no route go to the writer of the context, if any.
+// Use [WithRouter] to name the layer.
+//
+//	ctx = warnings.Route(ctx,
+//		warnings.When(warnings.Selector{Codes: []string{"SEC*"}}.Matches, securityCollector),
+//		warnings.ByKey(warnings.CategoryOf, map[string]warnings.Writer{"deprecation": deprecations}),
+//	)
+func Route(ctx context.Context, routes ...RouteRule) context.Context {
+	return route(ctx, routes, false)
+}
+
+// RouteAll is like [Route], but dispatches each warning to all the matching routes.
+func RouteAll(ctx context.Context, routes ...RouteRule) context.Context {
+	return route(ctx, routes, true)
+}
+
+func route(ctx context.Context, routes []RouteRule, all bool) context.Context {
+	return WithRouter(ctx, &Router{
+		Routes:  append([]RouteRule(nil), routes...),
+		All:     all,
+		Default: getWriter(ctx),
+	})
+}
+
+// WithRouter returns a new context that dispatches warnings with the router, replacing the writer of the context.
+// Unlike [Route], the warnings matching no route go to the default writer of the router only.
+// The router must not be modified afterwards.
+//
+//	ctx = warnings.WithRouter(ctx, &warnings.Router{
+//		Routes:  []warnings.RouteRule{warnings.When(isSecurity, securityCollector)},
+//		Default: warnings.Writer(collector),
+//	}, warnings.Named("security"))
+func WithRouter(ctx context.Context, r *Router, opts ...LayerOption) context.Context {
+	return setWriter(ctx, r, KindRoute, opts)
+}
@@ -0,0 +1,117 @@
+package warnings_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/runbed/warnings"
+)
+
+// ExampleRoute demonstrates how to dispatch warnings to different collectors.
+func ExampleRoute() {
+	// create the collectors
+	collector, security := warnings.NewCollector(), warnings.NewCollector()
+	defer collector.Close() // make sure to close the collectors when done
+	defer security.Close()
+	// attach the default collector to a context
+	ctx := warnings.Attach(context.Background(), collector)
+	// route the security warnings to a dedicated collector
+	ctx = warnings.Route(ctx, warnings.When(warnings.Selector{Codes: []string{"SEC*"}}.Matches, security))
+	warnings.Warnf(ctx, "this is a warning")
+	warnings.Warn(ctx, warnings.WithCode(warnings.New("this is a security warning"), "SEC001"))
+	// read all warnings from the collectors
+	for _, c := range []*warnings.Collector{collector, security} {
+		wrrs, err := warnings.ReadAll(c)
+		if err != nil {
+			// handle error
+		}
+		for _, wrr := range wrrs {
+			fmt.Println(wrr.Warn())
+		}
+	}
+	// Output:
+	// this is a warning
+	// this is a security warning
+}
+
+func TestRouter(t *testing.T) {
+	a, b, def := &mockWriter{}, &mockWriter{}, &mockWriter{}
+	r := &warnings.Router{
+		Routes: []warnings.RouteRule{
+			warnings.When(func(wrr warnings.Warning) bool { return wrr.Warn() != "c" }, a),
+			warnings.ByKey(warnings.CodeOf, map[string]warnings.Writer{"B": b}),
+		},
+		Default: def,
+	}
+	r.WriteWarning(warnings.New("a"))
+	r.WriteWarning(warnings.WithCode(warnings.New("b"), "B"))
+	r.WriteWarning(warnings.New("c"))
+	if len(a.buf) != 2 || len(b.buf) != 0 || len(def.buf) != 1 {
+		t.Fatalf("expected 2, 0 and 1 warnings, got %v, %v and %v", a.buf, b.buf, def.buf)
+	}
+	r.All = true
+	r.WriteWarning(warnings.WithCode(warnings.New("b"), "B"))
+	if len(a.buf) != 3 || len(b.buf) != 1 || len(def.buf) != 1 {
+		t.Fatalf("expected 3, 1 and 1 warnings, got %v, %v and %v", a.buf, b.buf, def.buf)
+	}
+}
+
+func TestRouter_Errors(t *testing.T) {
+	wantErr := fmt.Errorf("test-error")
+	r := &warnings.Router{
+		Routes: []warnings.RouteRule{
+			warnings.When(func(warnings.Warning) bool { return true }, &mockWriter{result: wantErr}),
+		},
+	}
+	if err := r.WriteWarning(warnings.New("test")); err != wantErr {
+		t.Fatalf("expected %v, got %v", wantErr, err)
+	}
+	r.Routes = nil
+	if err := r.WriteWarning(warnings.New("test")); err != nil {
+		t.Fatalf("expected nil, got %v", err)
+	}
+}
+
+func TestRouteAll(t *testing.T) {
+	a, b, def := &mockWriter{}, &mockWriter{}, &mockWriter{}
+	all := func(warnings.Warning) bool { return true }
+	ctx := warnings.Attach(context.Background(), def)
+	warnings.Warnf(warnings.RouteAll(ctx, warnings.When(all, a), warnings.When(all, b)), "test")
+	if len(a.buf) != 1 || len(b.buf) != 1 || len(def.buf) != 0 {
+		t.Fatalf("expected 1, 1 and 0 warnings, got %v, %v and %v", a.buf, b.buf, def.buf)
+	}
+}
+
+func TestRouteNoWriter(t *testing.T) {
+	w := &mockWriter{}
+	ctx := warnings.Route(context.Background(), warnings.When(func(wrr warnings.Warning) bool {
+		return wrr.Warn() == "routed"
+	}, w))
+	warnings.Warnf(ctx, "routed")
+	warnings.Warnf(ctx, "dropped")
+	if len(w.buf) != 1 {
+		t.Fatalf("expected 1 warning, got %v", w.buf)
+	}
+	if got := warnings.Describe(ctx).Kind; got != warnings.KindRoute {
+		t.Fatalf("expected %v, got %v", warnings.KindRoute, got)
+	}
+}
+
+func TestWithRouter(t *testing.T) {
+	w, parent := &mockWriter{}, &mockWriter{}
+	ctx := warnings.Attach(context.Background(), parent)
+	ctx = warnings.WithRouter(ctx, &warnings.Router{
+		Routes: []warnings.RouteRule{
+			warnings.When(func(wrr warnings.Warning) bool { return wrr.Warn() == "routed" }, w),
+		},
+	}, warnings.Named("routed"))
+	warnings.Warnf(ctx, "routed")
+	warnings.Warnf(ctx, "dropped")
+	if len(w.buf) != 1 || len(parent.buf) != 0 {
+		t.Fatalf("expected 1 and 0 warnings, got %v and %v", w.buf, parent.buf)
+	}
+	if l := warnings.Describe(ctx); l.Kind != warnings.KindRoute || l.Name != "routed" {
+		t.Fatalf("expected a %v layer named routed, got %v", warnings.KindRoute, l)
+	}
+}
@@ -0,0 +1,71 @@
+package warnings
+
+import (
+	"context"
+	"strings"
+)
+
+// Scope returns a new context whose warnings are tagged with the given scope name.
+// Scopes nest as contexts nest: the scope path of a warning written to a context
+// created by Scope(Scope(ctx, "load"), "parse") is "load/parse".
+// Use [ScopeOf] to read the scope path of a warning.
+// An empty name adds no segment and returns the same context.
+func Scope(ctx context.Context, name string) context.Context {
+	if name == "" {
+		return ctx
+	}
+	p := *getPipeline(ctx)
+	if p.scope != "" {
+		name = p.scope + "/" + name
+	}
+	p.scope = name
+	return setPipeline(ctx, p)
+}
+
+// ScopeOf returns the scope path of the warning, or an empty string if the warning is not scoped.
+// Warnings can carry their own scope by implementing a Scope() string method.
+func ScopeOf(wrr Warning) string {
+	s, ok := find[interface{ Scope() string }](wrr)
+	if !ok {
+		return ""
+	}
+	return s.Scope()
+}
+
+// InScope returns a predicate for [Filter] that reports whether a warning belongs to
+// the given scope path or to one of its sub-scopes.
+// For example, the scope "load" matches "load" and "load/parse" but not "loader".
+func InScope(prefix string) func(wrr Warning) bool {
+	return func(wrr Warning) bool {
+		scope := ScopeOf(wrr)
+		if !strings.HasPrefix(scope, prefix) {
+			return false
+		}
+		return len(scope) == len(prefix) || prefix == "" || scope[len(prefix)] == '/'
+	}
+}
+
+type scopedWarning struct {
+	Warning
+	scope string
+}
+
+// withScope tags the warning with the scope path unless it is empty or the warning is already scoped.
+func withScope(wrr Warning, scope string) Warning {
+	if wrr == nil || scope == "" || ScopeOf(wrr) != "" {
+		return wrr
+	}
+	return &scopedWarning{wrr, scope}
+}
+
+func (wrr *scopedWarning) Scope() string {
+	return wrr.scope
+}
+
+func (wrr *scopedWarning) Unwrap() Warning {
+	return wrr.Warning
+}
+
+func (wrr *scopedWarning) MarshalJSON() ([]byte, error) {
+	return marshalJSON(wrr)
+}
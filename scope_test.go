@@ -0,0 +1,104 @@
+package warnings_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/runbed/warnings"
+)
+
+// ExampleScope demonstrates how to use the Scope function to tag warnings with a scope path.
+func ExampleScope() {
+	// create a new collector
+	collector := warnings.NewCollector()
+	defer collector.Close() // make sure to close the collector when done
+	// attach the collector to a context
+	ctx := warnings.Attach(context.Background(), collector)
+	// use Scope to tag warnings, scopes nest as contexts nest
+	ctx = warnings.Scope(ctx, "load")
+	warnings.Warnf(ctx, "this is a warning 1")
+	ctx = warnings.Scope(ctx, "parse")
+	warnings.Warnf(ctx, "this is a warning 2")
+	// read all warnings from the collector
+	wrrs, err := warnings.ReadAll(collector)
+	if err != nil {
+		// handle error
+	}
+	for _, wrr := range wrrs {
+		fmt.Printf("%s: %s\n", warnings.ScopeOf(wrr), wrr.Warn())
+	}
+	// Output:
+	// load: this is a warning 1
+	// load/parse: this is a warning 2
+}
+
+func TestScope(t *testing.T) {
+	w := &mockWriter{}
+	ctx := warnings.Attach(context.Background(), w)
+	warnings.Warn(ctx, warnings.New("test-1"))
+	ctx = warnings.Scope(warnings.Scope(ctx, "load"), "parse")
+	warnings.Warn(ctx, warnings.New("test-2"))
+	if len(w.buf) != 2 {
+		t.Fatalf("expected 2 warnings, got %v", len(w.buf))
+	}
+	if got := warnings.ScopeOf(w.buf[0]); got != "" {
+		t.Fatalf("expected no scope, got %v", got)
+	}
+	if got := warnings.ScopeOf(w.buf[1]); got != "load/parse" {
+		t.Fatalf("expected load/parse, got %v", got)
+	}
+	if got := w.buf[1].Warn(); got != "test-2" {
+		t.Fatalf("expected test-2, got %v", got)
+	}
+	if got := warnings.Unwrap(w.buf[1]).Warn(); got != "test-2" {
+		t.Fatalf("expected test-2, got %v", got)
+	}
+}
+
+func TestScope_BeforeAttach(t *testing.T) {
+	w := &mockWriter{}
+	ctx := warnings.Scope(context.Background(), "load")
+	ctx = warnings.Attach(ctx, w)
+	warnings.Warnf(ctx, "test")
+	if len(w.buf) != 1 {
+		t.Fatalf("expected 1 warning, got %v", len(w.buf))
+	}
+	if got := warnings.ScopeOf(w.buf[0]); got != "load" {
+		t.Fatalf("expected load, got %v", got)
+	}
+}
+
+func TestInScope(t *testing.T) {
+	w := &mockWriter{}
+	ctx := warnings.Attach(context.Background(), w)
+	ctx = warnings.Filter(ctx, warnings.InScope("load"))
+	warnings.Warnf(warnings.Scope(ctx, "load"), "load")
+	warnings.Warnf(warnings.Scope(warnings.Scope(ctx, "load"), "parse"), "load/parse")
+	warnings.Warnf(warnings.Scope(ctx, "loader"), "loader")
+	warnings.Warnf(ctx, "none")
+	if len(w.buf) != 2 {
+		t.Fatalf("expected 2 warnings, got %v", w.buf)
+	}
+	if got := w.buf[0].Warn(); got != "load" {
+		t.Fatalf("expected load, got %v", got)
+	}
+	if got := w.buf[1].Warn(); got != "load/parse" {
+		t.Fatalf("expected load/parse, got %v", got)
+	}
+}
+
+func TestScope_Empty(t *testing.T) {
+	w := &mockWriter{}
+	ctx := warnings.Scope(warnings.Attach(context.Background(), w), "a")
+	if got := warnings.Scope(ctx, ""); got != ctx {
+		t.Fatalf("expected the same context")
+	}
+	warnings.Warnf(warnings.Scope(ctx, ""), "test")
+	if got := warnings.ScopeOf(w.buf[0]); got != "a" {
+		t.Fatalf("expected a, got %v", got)
+	}
+	if !warnings.InScope("a")(w.buf[0]) {
+		t.Fatalf("expected the warning to be in scope a")
+	}
+}
@@ -0,0 +1,102 @@
+package warnings
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"strings"
+	"sync"
+)
+
+// maxStackDepth is the maximum number of frames captured by [CaptureStack].
+const maxStackDepth = 64
+
+// CaptureStack returns a new context that captures the call stack of the written warnings selected by sel.
+// The stack is recorded when the warning is written with [Warn], even if it reaches the layer later,
+// e.g. when it is staged by [Begin] or collected by a [Group].
+// Capturing only records the program counters, frames are symbolized when the stack is first used.
+// Use [StackOf] to read the stack of a warning.
+func CaptureStack(ctx context.Context, sel Selector, opts ...LayerOption) context.Context {
+	if getWriter(ctx) == nil {
+		return ctx
+	}
+	ctx = addStage(ctx, KindStack, opts, func(wrr Warning) (Warning, error) {
+		if StackOf(wrr) != nil || !sel.Matches(wrr) {
+			return wrr, nil
+		}
+		if o, ok := find[*originWarning](wrr); ok && len(o.pcs) > 0 {
+			return &stackWarning{wrr, &Stack{pcs: o.pcs}}, nil
+		}
+		pcs := make([]uintptr, maxStackDepth)
+		return &stackWarning{wrr, &Stack{pcs: pcs[:runtime.Callers(2, pcs)]}}, nil
+	})
+	p := *getPipeline(ctx)
+	p.origin.depth = max(p.origin.depth, maxStackDepth)
+	return setPipeline(ctx, p)
+}
+
+// Stack is a call stack captured by [CaptureStack].
+type Stack struct {
+	pcs    []uintptr
+	once   sync.Once
+	frames []runtime.Frame
+}
+
+// StackOf returns the call stack of the warning, or nil if no stack was captured.
+// Warnings can carry their own stack by implementing a Stack() *[Stack] method.
+func StackOf(wrr Warning) *Stack {
+	s, ok := find[interface{ Stack() *Stack }](wrr)
+	if !ok {
+		return nil
+	}
+	return s.Stack()
+}
+
+// Frames returns the frames of the stack, starting with the caller that wrote the warning.
+// The frames of this package are omitted.
+func (s *Stack) Frames() []runtime.Frame {
+	s.once.Do(func() {
+		frames := runtime.CallersFrames(s.pcs)
+		for {
+			frame, more := frames.Next()
+			if len(s.frames) > 0 || !strings.HasPrefix(frame.Function, pkgPrefix) {
+				s.frames = append(s.frames, frame)
+			}
+			if !more {
+				break
+			}
+		}
+	})
+	return s.frames
+}
+
+// String returns the stack in the format used by Go for panics:
+//
+//	main.run(...)
+//		/path/to/main.go:12 +0x1d
+//	main.main(...)
+//		/path/to/main.go:5 +0x25
+func (s *Stack) String() string {
+	var b strings.Builder
+	for _, frame := range s.Frames() {
+		fmt.Fprintf(&b, "%s(...)\n\t%s:%d +%#x\n", frame.Function, frame.File, frame.Line, frame.PC-frame.Entry)
+	}
+	return b.String()
+}
+
+type stackWarning struct {
+	Warning
+	stack *Stack
+}
+
+func (wrr *stackWarning) Stack() *Stack {
+	return wrr.stack
+}
+
+func (wrr *stackWarning) Unwrap() Warning {
+	return wrr.Warning
+}
+
+func (wrr *stackWarning) MarshalJSON() ([]byte, error) {
+	return marshalJSON(wrr)
+}
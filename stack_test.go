@@ -0,0 +1,87 @@
+package warnings_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/runbed/warnings"
+)
+
+func TestCaptureStack(t *testing.T) {
+	w := &mockWriter{}
+	ctx := warnings.Attach(context.Background(), w)
+	ctx = warnings.CaptureStack(ctx, warnings.Selector{Codes: []string{"W1"}})
+	warnings.Warn(ctx, warnings.WithCode(warnings.New("test-1"), "W1"))
+	warnings.Warn(ctx, warnings.New("test-2"))
+	if len(w.buf) != 2 {
+		t.Fatalf("expected 2 warnings, got %v", w.buf)
+	}
+	if s := warnings.StackOf(w.buf[1]); s != nil {
+		t.Fatalf("expected no stack, got %v", s)
+	}
+	s := warnings.StackOf(w.buf[0])
+	if s == nil {
+		t.Fatalf("expected a stack")
+	}
+	frames := s.Frames()
+	if len(frames) == 0 {
+		t.Fatalf("expected frames")
+	}
+	if got := frames[0].Function; got != "github.com/runbed/warnings_test.TestCaptureStack" {
+		t.Fatalf("expected the first frame to be the caller, got %v", got)
+	}
+	str := s.String()
+	if !strings.HasPrefix(str, "github.com/runbed/warnings_test.TestCaptureStack(...)\n\t") {
+		t.Fatalf("expected a panic-like trace, got %v", str)
+	}
+	if !strings.Contains(str, "stack_test.go:") {
+		t.Fatalf("expected the file name, got %v", str)
+	}
+	if got := w.buf[0].Warn(); got != "test-1" {
+		t.Fatalf("expected test-1, got %v", got)
+	}
+}
+
+func TestCaptureStackNoWriter(t *testing.T) {
+	ctx := context.Background()
+	if got := warnings.CaptureStack(ctx, warnings.Selector{}); got != ctx {
+		t.Fatalf("expected the same context")
+	}
+}
+
+//go:noinline
+func emitStackWarning(ctx context.Context) {
+	warnings.Warnf(ctx, "test")
+}
+
+func TestCaptureStack_Deferred(t *testing.T) {
+	w := &mockWriter{}
+	ctx := warnings.Attach(context.Background(), w)
+	ctx = warnings.CaptureStack(ctx, warnings.Selector{})
+	txCtx, tx := warnings.Begin(ctx)
+	emitStackWarning(txCtx)
+	if err := tx.Commit(); err != nil {
+		t.Fatalf("expected nil, got %v", err)
+	}
+	group := warnings.NewGroup(ctx)
+	group.Go(func(ctx context.Context) error {
+		emitStackWarning(ctx)
+		return nil
+	})
+	if _, err := group.Wait(); err != nil {
+		t.Fatalf("expected nil, got %v", err)
+	}
+	if len(w.buf) != 2 {
+		t.Fatalf("expected 2 warnings, got %v", w.buf)
+	}
+	for _, wrr := range w.buf {
+		s := warnings.StackOf(wrr)
+		if s == nil || len(s.Frames()) == 0 {
+			t.Fatalf("expected a stack, got %v", s)
+		}
+		if got := s.Frames()[0].Function; got != "github.com/runbed/warnings_test.emitStackWarning" {
+			t.Fatalf("expected the first frame to be the writer of the warning, got %v", got)
+		}
+	}
+}
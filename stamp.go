@@ -0,0 +1,113 @@
+package warnings
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"sync/atomic"
+	"time"
+)
+
+// Stamped is the interface implemented by the warnings stamped by [Stamp].
+type Stamped interface {
+	// Seq returns the sequence number of the warning.
+	Seq() uint64
+	// Time returns the time at which the warning was written.
+	Time() time.Time
+}
+
+// seq is the last sequence number, shared by all the stamping layers of the process.
+var seq atomic.Uint64
+
+// Stamp returns a new context that stamps each written warning with a sequence number and a timestamp.
+// Sequence numbers are monotonic across the process, so warnings stamped by different layers,
+// goroutines or collectors can be merged in a total order, see [ReadAllSorted].
+// The timestamp is read from clock, or from [time.Now] if clock is nil.
+// Warnings are stamped when they are written with [Warn], even if they reach the layer later,
+// e.g. when they are staged by [Begin] or collected by a [Group]. If several stamping layers
+// are nested, the clock of the last one is used.
+// Warnings that are already stamped keep their original stamp.
+func Stamp(ctx context.Context, clock func() time.Time, opts ...LayerOption) context.Context {
+	if getWriter(ctx) == nil {
+		return ctx
+	}
+	if clock == nil {
+		clock = time.Now
+	}
+	ctx = addStage(ctx, KindStamp, opts, func(wrr Warning) (Warning, error) {
+		if _, ok := StampOf(wrr); ok {
+			return wrr, nil
+		}
+		if o, ok := find[*originWarning](wrr); ok && o.stamped {
+			return &stampedWarning{wrr, o.seq, o.time}, nil
+		}
+		return &stampedWarning{wrr, seq.Add(1), clock()}, nil
+	})
+	p := *getPipeline(ctx)
+	p.origin.clock = clock
+	return setPipeline(ctx, p)
+}
+
+// StampOf returns the stamp of the warning, if it is stamped.
+func StampOf(wrr Warning) (Stamped, bool) {
+	return find[Stamped](wrr)
+}
+
+// CompareStamps compares two warnings by sequence number, then by time.
+// Stamped warnings come before warnings that are not stamped.
+func CompareStamps(a, b Warning) int {
+	sa, okA := StampOf(a)
+	sb, okB := StampOf(b)
+	switch {
+	case !okA || !okB:
+		return cmp.Compare(boolToInt(!okA), boolToInt(!okB))
+	case sa.Seq() != sb.Seq():
+		return cmp.Compare(sa.Seq(), sb.Seq())
+	}
+	return sa.Time().Compare(sb.Time())
+}
+
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// ReadAllSorted reads all the warnings from the readers and sorts them with [CompareStamps].
+// The warnings that are not stamped keep their relative order, after the stamped ones.
+// It stops reading when it encounters an error.
+func ReadAllSorted(readers ...Reader) ([]Warning, error) {
+	var result []Warning
+	for _, r := range readers {
+		wrrs, err := ReadAll(r)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, wrrs...)
+	}
+	slices.SortStableFunc(result, CompareStamps)
+	return result, nil
+}
+
+type stampedWarning struct {
+	Warning
+	seq  uint64
+	time time.Time
+}
+
+func (wrr *stampedWarning) Seq() uint64 {
+	return wrr.seq
+}
+
+func (wrr *stampedWarning) Time() time.Time {
+	return wrr.time
+}
+
+func (wrr *stampedWarning) Unwrap() Warning {
+	return wrr.Warning
+}
+
+func (wrr *stampedWarning) MarshalJSON() ([]byte, error) {
+	return marshalJSON(wrr)
+}
@@ -0,0 +1,161 @@
+package warnings_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/runbed/warnings"
+)
+
+// ExampleReadAllSorted demonstrates how to merge stamped warnings from several collectors in a total order.
+func ExampleReadAllSorted() {
+	// create two collectors
+	c1, c2 := warnings.NewCollector(), warnings.NewCollector()
+	defer c1.Close() // make sure to close the collectors when done
+	defer c2.Close()
+	// attach the collectors to stamped contexts
+	ctx1 := warnings.Stamp(warnings.Attach(context.Background(), c1), nil)
+	ctx2 := warnings.Stamp(warnings.Attach(context.Background(), c2), nil)
+	// use Warn or Warnf to write warnings to the contexts
+	warnings.Warnf(ctx1, "this is a warning 1")
+	warnings.Warnf(ctx2, "this is a warning 2")
+	warnings.Warnf(ctx1, "this is a warning 3")
+	// read all warnings from both collectors in the order they were written
+	wrrs, err := warnings.ReadAllSorted(c1, c2)
+	if err != nil {
+		// handle error
+	}
+	for _, wrr := range wrrs {
+		fmt.Println(wrr.Warn())
+	}
+	// Output:
+	// this is a warning 1
+	// this is a warning 2
+	// this is a warning 3
+}
+
+func TestStamp(t *testing.T) {
+	now := time.Date(2024, 4, 13, 0, 0, 0, 0, time.UTC)
+	w := &mockWriter{}
+	ctx := warnings.Attach(context.Background(), w)
+	ctx = warnings.Stamp(ctx, func() time.Time { return now })
+	warnings.Warnf(ctx, "test-1")
+	warnings.Warnf(ctx, "test-2")
+	if len(w.buf) != 2 {
+		t.Fatalf("expected 2 warnings, got %v", w.buf)
+	}
+	s1, ok := warnings.StampOf(w.buf[0])
+	if !ok {
+		t.Fatalf("expected a stamped warning")
+	}
+	s2, ok := warnings.StampOf(w.buf[1])
+	if !ok {
+		t.Fatalf("expected a stamped warning")
+	}
+	if s1.Seq() >= s2.Seq() {
+		t.Fatalf("expected increasing sequence numbers, got %v and %v", s1.Seq(), s2.Seq())
+	}
+	if !s1.Time().Equal(now) {
+		t.Fatalf("expected %v, got %v", now, s1.Time())
+	}
+	if got := w.buf[0].Warn(); got != "test-1" {
+		t.Fatalf("expected test-1, got %v", got)
+	}
+}
+
+func TestStamp_Begin(t *testing.T) {
+	now := time.Date(2024, 4, 13, 0, 0, 0, 0, time.UTC)
+	w := &mockWriter{}
+	ctx := warnings.Attach(context.Background(), w)
+	ctx = warnings.Stamp(ctx, func() time.Time { return now })
+	txCtx, tx := warnings.Begin(ctx)
+	warnings.Warnf(txCtx, "staged")
+	written := now
+	now = now.Add(time.Minute)
+	warnings.Warnf(ctx, "direct")
+	if err := tx.Commit(); err != nil {
+		t.Fatalf("expected nil, got %v", err)
+	}
+	if len(w.buf) != 2 {
+		t.Fatalf("expected 2 warnings, got %v", w.buf)
+	}
+	staged, _ := warnings.StampOf(w.buf[1])
+	direct, _ := warnings.StampOf(w.buf[0])
+	if staged == nil || direct == nil {
+		t.Fatalf("expected stamped warnings, got %v", w.buf)
+	}
+	if !staged.Time().Equal(written) {
+		t.Fatalf("expected the staged warning to be stamped when written at %v, got %v", written, staged.Time())
+	}
+	wrrs, err := warnings.ReadAllSorted(collectorOfWarnings(w.buf...))
+	if err != nil {
+		t.Fatalf("expected nil, got %v", err)
+	}
+	if wrrs[0].Warn() != "staged" {
+		t.Fatalf("expected the staged warning first, got %v", wrrs)
+	}
+}
+
+func collectorOfWarnings(wrrs ...warnings.Warning) *warnings.Collector {
+	c := warnings.NewCollector()
+	for _, wrr := range wrrs {
+		c.WriteWarning(wrr)
+	}
+	return c
+}
+
+func TestStamp_KeepsStamp(t *testing.T) {
+	w := &mockWriter{}
+	ctx := warnings.Attach(context.Background(), w)
+	ctx = warnings.Stamp(ctx, nil)
+	inner := warnings.Stamp(ctx, nil)
+	warnings.Warnf(inner, "test")
+	s, ok := warnings.StampOf(w.buf[0])
+	if !ok {
+		t.Fatalf("expected a stamped warning")
+	}
+	if unwrapped, ok := warnings.StampOf(warnings.Unwrap(w.buf[0])); ok {
+		t.Fatalf("expected a single stamp, got %v and %v", s, unwrapped)
+	}
+}
+
+func TestStampNoWriter(t *testing.T) {
+	ctx := context.Background()
+	if got := warnings.Stamp(ctx, nil); got != ctx {
+		t.Fatalf("expected the same context")
+	}
+}
+
+func TestCompareStamps(t *testing.T) {
+	w := &mockWriter{}
+	ctx := warnings.Stamp(warnings.Attach(context.Background(), w), nil)
+	warnings.Warnf(ctx, "test-1")
+	warnings.Warnf(ctx, "test-2")
+	plain := warnings.New("plain")
+	tests := []struct {
+		a, b warnings.Warning
+		want int
+	}{
+		{w.buf[0], w.buf[1], -1},
+		{w.buf[1], w.buf[0], 1},
+		{w.buf[0], w.buf[0], 0},
+		{w.buf[0], plain, -1},
+		{plain, w.buf[0], 1},
+		{plain, plain, 0},
+	}
+	for i, tt := range tests {
+		if got := warnings.CompareStamps(tt.a, tt.b); got != tt.want {
+			t.Errorf("%d: expected %v, got %v", i, tt.want, got)
+		}
+	}
+}
+
+func TestReadAllSorted_Error(t *testing.T) {
+	wantErr := fmt.Errorf("test-error")
+	r := &mockReader{[]mockReaderResult{{nil, wantErr}}}
+	if _, err := warnings.ReadAllSorted(r); err != wantErr {
+		t.Fatalf("expected %v, got %v", wantErr, err)
+	}
+}
@@ -0,0 +1,45 @@
+package warnings
+
+import "context"
+
+// StrictPolicy defines which warnings are treated as errors by [Strict].
+type StrictPolicy struct {
+	// Selector selects the warnings treated as errors.
+	// The zero value treats every warning as an error.
+	Selector
+	// Record writes the offending warnings to the underlying writer as well.
+	Record bool
+}
+
+// StrictError is the error returned by [Warn] for a warning treated as an error.
+// It matches [ErrStrict] when using [errors.Is].
+type StrictError struct {
+	Warning Warning
+}
+
+func (e *StrictError) Error() string {
+	return ErrStrict.Error() + ": " + e.Warning.Warn()
+}
+
+func (e *StrictError) Unwrap() error {
+	return ErrStrict
+}
+
+// Strict returns a new context that treats the warnings selected by the policy as errors,
+// like -Werror in compilers: [Warn] returns a [StrictError] wrapping each offending warning.
+// Offending warnings are not written to the underlying writer unless [StrictPolicy.Record] is set.
+// Unlike other helpers, it applies even when no writer is attached to the context.
+func Strict(ctx context.Context, policy StrictPolicy, opts ...LayerOption) context.Context {
+	return addStage(ctx, KindStrict, opts, policy.apply)
+}
+
+func (policy StrictPolicy) apply(wrr Warning) (Warning, error) {
+	if !policy.Matches(wrr) {
+		return wrr, nil
+	}
+	err := &StrictError{wrr}
+	if !policy.Record {
+		return nil, err
+	}
+	return wrr, err
+}
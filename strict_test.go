@@ -0,0 +1,90 @@
+package warnings_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/runbed/warnings"
+)
+
+// ExampleStrict demonstrates how to use the Strict function to treat warnings as errors.
+func ExampleStrict() {
+	// create a new collector
+	collector := warnings.NewCollector()
+	defer collector.Close() // make sure to close the collector when done
+	// attach the collector to a context
+	ctx := warnings.Attach(context.Background(), collector)
+	// use Strict to treat high severity warnings as errors
+	ctx = warnings.Strict(ctx, warnings.StrictPolicy{
+		Selector: warnings.Selector{MinSeverity: warnings.SeverityHigh},
+	})
+	err := warnings.Warn(ctx, warnings.New("this is a warning"))
+	fmt.Println(err)
+	err = warnings.Warn(ctx, warnings.WithSeverity(warnings.New("this is an error"), warnings.SeverityHigh))
+	fmt.Println(err)
+	// Output:
+	// <nil>
+	// warning treated as error: this is an error
+}
+
+func TestStrict(t *testing.T) {
+	w := &mockWriter{}
+	ctx := warnings.Attach(context.Background(), w)
+	ctx = warnings.Strict(ctx, warnings.StrictPolicy{Selector: warnings.Selector{Codes: []string{"W1"}}})
+	if err := warnings.Warn(ctx, warnings.New("test-1")); err != nil {
+		t.Fatalf("expected nil, got %v", err)
+	}
+	want := warnings.WithCode(warnings.New("test-2"), "W1")
+	err := warnings.Warn(ctx, want)
+	if !errors.Is(err, warnings.ErrStrict) {
+		t.Fatalf("expected %v, got %v", warnings.ErrStrict, err)
+	}
+	var strictErr *warnings.StrictError
+	if !errors.As(err, &strictErr) {
+		t.Fatalf("expected a strict error, got %v", err)
+	}
+	if strictErr.Warning != want {
+		t.Fatalf("expected %v, got %v", want, strictErr.Warning)
+	}
+	if len(w.buf) != 1 {
+		t.Fatalf("expected 1 warning, got %v", w.buf)
+	}
+}
+
+func TestStrict_Record(t *testing.T) {
+	w := &mockWriter{}
+	ctx := warnings.Attach(context.Background(), w)
+	ctx = warnings.Strict(ctx, warnings.StrictPolicy{Record: true})
+	if err := warnings.Warnf(ctx, "test"); !errors.Is(err, warnings.ErrStrict) {
+		t.Fatalf("expected %v, got %v", warnings.ErrStrict, err)
+	}
+	if len(w.buf) != 1 {
+		t.Fatalf("expected 1 warning, got %v", w.buf)
+	}
+}
+
+func TestStrict_RecordError(t *testing.T) {
+	wantErr := fmt.Errorf("test-error")
+	ctx := warnings.Attach(context.Background(), &mockWriter{result: wantErr})
+	ctx = warnings.Strict(ctx, warnings.StrictPolicy{Record: true})
+	err := warnings.Warnf(ctx, "test")
+	if !errors.Is(err, warnings.ErrStrict) {
+		t.Fatalf("expected %v, got %v", warnings.ErrStrict, err)
+	}
+	if !errors.Is(err, wantErr) {
+		t.Fatalf("expected %v, got %v", wantErr, err)
+	}
+}
+
+func TestStrictNoWriter(t *testing.T) {
+	ctx := warnings.Strict(context.Background(), warnings.StrictPolicy{Record: true})
+	if err := warnings.Warnf(ctx, "test"); !errors.Is(err, warnings.ErrStrict) {
+		t.Fatalf("expected %v, got %v", warnings.ErrStrict, err)
+	}
+	ctx = warnings.Detach(ctx)
+	if err := warnings.Warnf(ctx, "test"); err != nil {
+		t.Fatalf("expected nil, got %v", err)
+	}
+}
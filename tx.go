@@ -0,0 +1,58 @@
+package warnings
+
+import (
+	"context"
+	"errors"
+)
+
+// Tx is a transaction that stages the warnings written to a context, see [Begin].
+// It is safe to write warnings to the context of a transaction concurrently.
+type Tx struct {
+	w      Writer
+	staged *Collector
+}
+
+// Begin returns a new context whose warnings are staged in a transaction instead of being written.
+// Call [Tx.Commit] to forward the staged warnings to the writer of the parent context,
+// or [Tx.Rollback] to discard them, e.g. when an operation fails and is retried.
+// Transactions nest: committing a nested transaction stages its warnings in the outer one.
+// If no writer is attached to the context, it returns the same context and a no-op transaction.
+func Begin(ctx context.Context, opts ...LayerOption) (context.Context, *Tx) {
+	w := getWriter(ctx)
+	if w == nil {
+		// nothing is ever staged, but the transaction still ends like any other
+		return ctx, &Tx{staged: NewCollector()}
+	}
+	tx := &Tx{w: w, staged: NewCollector()}
+	return setWriter(ctx, tx.staged, KindBegin, opts), tx
+}
+
+// Commit writes the staged warnings to the parent writer in order and ends the transaction.
+// If any of the warnings fail to write, all the errors are returned as one error.
+// It returns [ErrClosed] if the transaction has already ended.
+func (tx *Tx) Commit() error {
+	wrrs, err := tx.staged.drain()
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, wrr := range wrrs {
+		if err := tx.w.WriteWarning(wrr); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Rollback discards the staged warnings and ends the transaction.
+// It returns [ErrClosed] if the transaction has already ended.
+func (tx *Tx) Rollback() error {
+	_, err := tx.discard()
+	return err
+}
+
+// discard discards the staged warnings, ends the transaction and returns the number of discarded warnings.
+func (tx *Tx) discard() (int, error) {
+	wrrs, err := tx.staged.drain()
+	return len(wrrs), err
+}
@@ -0,0 +1,158 @@
+package warnings_test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/runbed/warnings"
+)
+
+// ExampleBegin demonstrates how to use a transaction to discard the warnings of a failed attempt.
+func ExampleBegin() {
+	// create a new collector
+	collector := warnings.NewCollector()
+	defer collector.Close() // make sure to close the collector when done
+	// attach the collector to a context
+	ctx := warnings.Attach(context.Background(), collector)
+	// stage the warnings of the first attempt and discard them
+	txCtx, tx := warnings.Begin(ctx)
+	warnings.Warnf(txCtx, "this is a warning from attempt 1")
+	tx.Rollback()
+	// stage the warnings of the second attempt and commit them
+	txCtx, tx = warnings.Begin(ctx)
+	warnings.Warnf(txCtx, "this is a warning from attempt 2")
+	if err := tx.Commit(); err != nil {
+		// handle error
+	}
+	// read all warnings from the collector
+	wrrs, err := warnings.ReadAll(collector)
+	if err != nil {
+		// handle error
+	}
+	for _, wrr := range wrrs {
+		fmt.Println(wrr.Warn())
+	}
+	// Output:
+	// this is a warning from attempt 2
+}
+
+func TestBegin_Commit(t *testing.T) {
+	w := &mockWriter{}
+	ctx := warnings.Attach(context.Background(), w)
+	txCtx, tx := warnings.Begin(ctx)
+	warnings.Warnf(txCtx, "test-1")
+	warnings.Warnf(txCtx, "test-2")
+	if len(w.buf) != 0 {
+		t.Fatalf("expected no warnings before commit, got %v", w.buf)
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatalf("expected nil, got %v", err)
+	}
+	if len(w.buf) != 2 || w.buf[0].Warn() != "test-1" || w.buf[1].Warn() != "test-2" {
+		t.Fatalf("expected [test-1 test-2], got %v", w.buf)
+	}
+	if err := tx.Commit(); err != warnings.ErrClosed {
+		t.Fatalf("expected %v, got %v", warnings.ErrClosed, err)
+	}
+	if err := tx.Rollback(); err != warnings.ErrClosed {
+		t.Fatalf("expected %v, got %v", warnings.ErrClosed, err)
+	}
+	if err := warnings.Warnf(txCtx, "test-3"); err == nil {
+		t.Fatalf("expected error, got nil")
+	}
+}
+
+func TestBegin_CommitError(t *testing.T) {
+	wantErr := fmt.Errorf("test-error")
+	ctx := warnings.Attach(context.Background(), &mockWriter{result: wantErr})
+	txCtx, tx := warnings.Begin(ctx)
+	if err := warnings.Warnf(txCtx, "test"); err != nil {
+		t.Fatalf("expected nil, got %v", err)
+	}
+	if err := tx.Commit(); err == nil {
+		t.Fatalf("expected %v, got nil", wantErr)
+	}
+}
+
+func TestBegin_Rollback(t *testing.T) {
+	w := &mockWriter{}
+	ctx := warnings.Attach(context.Background(), w)
+	txCtx, tx := warnings.Begin(ctx)
+	warnings.Warnf(txCtx, "test")
+	if err := tx.Rollback(); err != nil {
+		t.Fatalf("expected nil, got %v", err)
+	}
+	if len(w.buf) != 0 {
+		t.Fatalf("expected no warnings, got %v", w.buf)
+	}
+	if err := tx.Commit(); err != warnings.ErrClosed {
+		t.Fatalf("expected %v, got %v", warnings.ErrClosed, err)
+	}
+}
+
+func TestBegin_Nested(t *testing.T) {
+	w := &mockWriter{}
+	ctx := warnings.Attach(context.Background(), w)
+	outerCtx, outer := warnings.Begin(ctx)
+	warnings.Warnf(outerCtx, "outer")
+	innerCtx, inner := warnings.Begin(outerCtx)
+	warnings.Warnf(innerCtx, "inner")
+	if err := inner.Commit(); err != nil {
+		t.Fatalf("expected nil, got %v", err)
+	}
+	if len(w.buf) != 0 {
+		t.Fatalf("expected no warnings before the outer commit, got %v", w.buf)
+	}
+	if err := outer.Commit(); err != nil {
+		t.Fatalf("expected nil, got %v", err)
+	}
+	if len(w.buf) != 2 {
+		t.Fatalf("expected 2 warnings, got %v", w.buf)
+	}
+}
+
+func TestBegin_Concurrent(t *testing.T) {
+	c := warnings.NewCollector()
+	defer c.Close()
+	ctx := warnings.Attach(context.Background(), c)
+	txCtx, tx := warnings.Begin(ctx)
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			warnings.Warnf(txCtx, "test")
+		}()
+	}
+	wg.Wait()
+	if err := tx.Commit(); err != nil {
+		t.Fatalf("expected nil, got %v", err)
+	}
+	wrrs, err := warnings.ReadAll(c)
+	if err != nil {
+		t.Fatalf("expected nil, got %v", err)
+	}
+	if len(wrrs) != 10 {
+		t.Fatalf("expected 10 warnings, got %v", len(wrrs))
+	}
+}
+
+func TestBeginNoWriter(t *testing.T) {
+	ctx := context.Background()
+	txCtx, tx := warnings.Begin(ctx)
+	if txCtx != ctx {
+		t.Fatalf("expected the same context")
+	}
+	warnings.Warnf(txCtx, "test")
+	if err := tx.Commit(); err != nil {
+		t.Fatalf("expected nil, got %v", err)
+	}
+	if err := tx.Commit(); err != warnings.ErrClosed {
+		t.Fatalf("expected %v, got %v", warnings.ErrClosed, err)
+	}
+	if err := tx.Rollback(); err != warnings.ErrClosed {
+		t.Fatalf("expected %v, got %v", warnings.ErrClosed, err)
+	}
+}
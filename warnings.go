@@ -34,13 +34,26 @@
 //
 // Use [Map], [Filter], [Reduce] or [Tap] helper functions to apply transformations,
 // filters or side-effects to the warnings.
+//
+// Use [Begin] to stage warnings in a transaction that can be committed or rolled back.
+//
+// Use [Scope] to tag the warnings written to a context with a hierarchical scope path.
+//
+//	ctx = warnings.Scope(ctx, "parse")
+//
+// Use [WithAttrs] to enrich the warnings written to a context with attributes.
+//
+//	ctx = warnings.WithAttrs(ctx, slog.String("request_id", id))
+//
+// Use [Strict] to treat selected warnings as errors, or [WithPolicy] to apply
+// declarative rules modeled after Python warning filters.
 package warnings
 
 import (
 	"context"
 	"encoding/json"
 	"errors"
-	"fmt"
+	"slices"
 )
 
 // Warning is an interface representing a warning.
@@ -69,66 +82,99 @@ func New(str string) Warning {
 	return &warningString{str}
 }
 
-type writerKey struct{}
-
-func setWriter(ctx context.Context, w Writer) context.Context {
-	return context.WithValue(ctx, writerKey{}, w)
-}
-
-func getWriter(ctx context.Context) Writer {
-	w, ok := ctx.Value(writerKey{}).(Writer)
+// Unwrap returns the warning wrapped by wrr, if wrr has an Unwrap method returning a [Warning].
+// Otherwise, it returns nil.
+func Unwrap(wrr Warning) Warning {
+	u, ok := wrr.(interface{ Unwrap() Warning })
 	if !ok {
 		return nil
 	}
-	return w
+	return u.Unwrap()
 }
 
-func resetWriter(ctx context.Context, w Writer) context.Context {
-	return setWriter(setWriter(ctx, nil), w)
+// find returns the first warning in the chain of wrapped warnings that implements T.
+func find[T any](wrr Warning) (T, bool) {
+	for wrr != nil {
+		if t, ok := wrr.(T); ok {
+			return t, true
+		}
+		wrr = Unwrap(wrr)
+	}
+	return *new(T), false
 }
 
 // Warn writes warnings to the context. When multiple warnings are provided, they are written in order.
-// If no writer is attached to the context, it does nothing and returns nil.
+// If writing a warning has no effect, see [Enabled], it does nothing and returns nil.
+// The warnings are tagged with the scope and the attributes of the context, see [Scope] and [WithAttrs].
 // If any of the warnings fail to write, all the warnings are returned as one error.
 func Warn(ctx context.Context, wrrs ...Warning) error {
-	w := getWriter(ctx)
-	if w == nil {
+	p := getPipeline(ctx)
+	if p.w == nil {
+		if p.auditsDetach() {
+			for _, wrr := range wrrs {
+				audit(p.audit, p.layer, wrr, nil, nil)
+			}
+		}
 		return nil
 	}
 	var errs []error
 	for _, wrr := range wrrs {
-		if err := w.WriteWarning(wrr); err != nil {
+		wrr = withAttrs(withScope(wrr, p.scope), p.attrs)
+		wrr = withOrigin(wrr, p.origin)
+		if err := p.w.WriteWarning(wrr); err != nil {
 			errs = append(errs, err)
 		}
 	}
 	return errors.Join(errs...)
 }
 
+// WarnFunc writes the warning returned by fn to the context.
+// If writing a warning has no effect, see [Enabled], fn is not called,
+// which avoids building expensive diagnostics that nobody listens to.
+func WarnFunc(ctx context.Context, fn func() Warning) error {
+	if !getPipeline(ctx).listening() {
+		return nil
+	}
+	return Warn(ctx, fn())
+}
+
+// Enabled reports whether writing a warning to the context can have any effect,
+// e.g. a writer captures it, a [Strict] layer turns it into an error or an [Audit] records it.
+// It can be used to skip building expensive diagnostics.
+func Enabled(ctx context.Context) bool {
+	return getPipeline(ctx).listening()
+}
+
 // Warnf is a helper function that formats the warning and writes it to the context.
 // If the format string contains any [Warning] arguments, they are converted to strings before formatting.
+// The warning keeps the format string and a copy of the arguments, see [TemplateOf] and [ArgsOf].
+// Use [Lazyf] to defer the formatting until the message is needed.
+// If writing a warning has no effect, see [Enabled], the warning is not created.
 func Warnf(ctx context.Context, format string, args ...any) error {
-	for i, arg := range args {
-		if wrr, ok := arg.(Warning); ok {
-			args[i] = wrr.Warn()
-		}
+	if !getPipeline(ctx).listening() {
+		return nil
 	}
-	return Warn(ctx, &warningString{fmt.Sprintf(format, args...)})
+	s := sprintf(format, args...)
+	return Warn(ctx, formatted(format, slices.Clip(slices.Clone(args)), s))
 }
 
 // Attach returns a new context that collects warnings using the provided writer.
 // If a writer is already attached to the context, it creates a new writer that writes to both.
-func Attach(ctx context.Context, w Writer) context.Context {
+func Attach(ctx context.Context, w Writer, opts ...LayerOption) context.Context {
+	opts = append([]LayerOption{attached(w)}, opts...)
 	if found := getWriter(ctx); found != nil {
 		w = NewMultiWriter(found, w)
 	}
-	return setWriter(ctx, w)
+	return setWriter(ctx, w, KindAttach, opts)
 }
 
 // Detach returns a new context that does not propagate warnings up the chain.
-func Detach(ctx context.Context) context.Context {
+func Detach(ctx context.Context, opts ...LayerOption) context.Context {
 	w := getWriter(ctx)
 	if w == nil {
 		return ctx
 	}
-	return setWriter(ctx, nil)
+	p := *getPipeline(ctx)
+	p.w, p.layer, p.origin = nil, newLayer(KindDetach, nil, opts), originOptions{}
+	return setPipeline(ctx, p)
 }
@@ -108,6 +108,202 @@ func TestWarnf(t *testing.T) {
 	}
 }
 
+func TestWarnf_Template(t *testing.T) {
+	w := &mockWriter{}
+	ctx := warnings.Attach(context.Background(), w)
+	sub := warnings.New("sub-warning")
+	args := []any{sub, 42}
+	err := warnings.Warnf(ctx, "test-warning: %s %d", args...)
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if args[0] != sub {
+		t.Fatalf("expected the arguments not to be modified, got %v", args)
+	}
+	args[1] = 0
+	if len(w.buf) != 1 {
+		t.Fatalf("expected 1 warning, got %v", w.buf)
+	}
+	if got, want := w.buf[0].Warn(), "test-warning: sub-warning 42"; got != want {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	if got, want := warnings.TemplateOf(w.buf[0]), "test-warning: %s %d"; got != want {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	if got := warnings.ArgsOf(w.buf[0]); len(got) != 2 || got[0] != sub || got[1] != 42 {
+		t.Fatalf("expected [%v 42], got %v", sub, got)
+	}
+}
+
+func TestWarnf_Eager(t *testing.T) {
+	type config struct{ N int }
+	w := &mockWriter{}
+	ctx := warnings.Attach(context.Background(), w)
+	cfg := &config{N: 1}
+	if err := warnings.Warnf(ctx, "value %+v", cfg); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	cfg.N = 2
+	if got, want := w.buf[0].Warn(), "value &{N:1}"; got != want {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+}
+
+func TestTemplateOf(t *testing.T) {
+	wrr := warnings.New("test-warning")
+	if got := warnings.TemplateOf(wrr); got != "test-warning" {
+		t.Fatalf("expected test-warning, got %v", got)
+	}
+	if got := warnings.ArgsOf(wrr); got != nil {
+		t.Fatalf("expected no arguments, got %v", got)
+	}
+	wrr = warnings.WithCode(warnings.Lazyf("test: %d", 1), "W1")
+	if got := warnings.TemplateOf(wrr); got != "test: %d" {
+		t.Fatalf("expected test: %%d, got %v", got)
+	}
+}
+
+func TestWarnf_NoWriter(t *testing.T) {
+	arg := &countingStringer{}
+	if err := warnings.Warnf(context.Background(), "test: %s", arg); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if arg.calls != 0 {
+		t.Fatalf("expected the warning not to be formatted")
+	}
+}
+
+func TestWarnFunc(t *testing.T) {
+	want := warnings.New("test-warning")
+	w := &mockWriter{}
+	ctx := warnings.Attach(context.Background(), w)
+	err := warnings.WarnFunc(ctx, func() warnings.Warning {
+		return want
+	})
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if len(w.buf) != 1 || w.buf[0] != want {
+		t.Fatalf("expected %v, got %v", want, w.buf)
+	}
+}
+
+func TestWarnFuncNoWriter(t *testing.T) {
+	called := false
+	err := warnings.WarnFunc(context.Background(), func() warnings.Warning {
+		called = true
+		return warnings.New("test-warning")
+	})
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if called {
+		t.Fatalf("expected fn not to be called")
+	}
+}
+
+func TestEnabled(t *testing.T) {
+	ctx := context.Background()
+	if warnings.Enabled(ctx) {
+		t.Fatalf("expected disabled without writer")
+	}
+	ctx = warnings.Attach(ctx, &mockWriter{})
+	if !warnings.Enabled(ctx) {
+		t.Fatalf("expected enabled with writer")
+	}
+	if warnings.Enabled(warnings.Detach(ctx)) {
+		t.Fatalf("expected disabled after detach")
+	}
+	// a strict layer turns warnings into errors even if nothing captures them
+	if !warnings.Enabled(warnings.Strict(context.Background(), warnings.StrictPolicy{})) {
+		t.Fatalf("expected enabled with a strict layer")
+	}
+}
+
+func TestNoWriterAllocs(t *testing.T) {
+	ctx := context.Background()
+	wrr := warnings.New("test-warning")
+	tests := map[string]func(){
+		"Enabled": func() {
+			warnings.Enabled(ctx)
+		},
+		"Warn": func() {
+			warnings.Warn(ctx, wrr)
+		},
+		"WarnFunc": func() {
+			warnings.WarnFunc(ctx, func() warnings.Warning {
+				return warnings.New("test-warning")
+			})
+		},
+	}
+	for name, fn := range tests {
+		if allocs := testing.AllocsPerRun(100, fn); allocs != 0 {
+			t.Errorf("%s: expected no allocations, got %v", name, allocs)
+		}
+	}
+}
+
+func BenchmarkEnabled_NoWriter(b *testing.B) {
+	ctx := context.Background()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		warnings.Enabled(ctx)
+	}
+}
+
+func BenchmarkWarn_NoWriter(b *testing.B) {
+	ctx := context.Background()
+	wrr := warnings.New("test-warning")
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		warnings.Warn(ctx, wrr)
+	}
+}
+
+func BenchmarkWarnFunc_NoWriter(b *testing.B) {
+	ctx := context.Background()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		warnings.WarnFunc(ctx, func() warnings.Warning {
+			return warnings.Lazyf("test-warning %d", i)
+		})
+	}
+}
+
+func BenchmarkWarnf_NoWriter(b *testing.B) {
+	ctx := context.Background()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		warnings.Warnf(ctx, "test-warning %d", i)
+	}
+}
+
+func BenchmarkWarnf(b *testing.B) {
+	c := warnings.NewCollector()
+	defer c.Close()
+	ctx := warnings.Attach(context.Background(), c)
+	ctx = warnings.Filter(ctx, func(wrr warnings.Warning) bool {
+		return false
+	})
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		warnings.Warnf(ctx, "test-warning %d", i)
+	}
+}
+
+func BenchmarkWarnLazyf(b *testing.B) {
+	c := warnings.NewCollector()
+	defer c.Close()
+	ctx := warnings.Attach(context.Background(), c)
+	ctx = warnings.Filter(ctx, func(wrr warnings.Warning) bool {
+		return false
+	})
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		warnings.Warn(ctx, warnings.Lazyf("test-warning %d", i))
+	}
+}
+
 func TestAttach(t *testing.T) {
 	wrrs := []warnings.Warning{
 		warnings.New("test-1"),
@@ -8,13 +8,30 @@ type Writer interface {
 	WriteWarning(wrr Warning) error
 }
 
+// WriterFunc is an adapter to allow the use of ordinary functions as a [Writer].
+type WriterFunc func(wrr Warning) error
+
+// WriteWarning calls f(wrr).
+func (f WriterFunc) WriteWarning(wrr Warning) error {
+	return f(wrr)
+}
+
 type multiWriter struct {
 	writers []Writer
 }
 
 // NewMultiWriter returns a Writer that duplicates its writes to all the provided writers.
+// Multi-writers passed as arguments are flattened, so that writes do not fan out recursively.
 func NewMultiWriter(writers ...Writer) Writer {
-	return &multiWriter{writers}
+	all := make([]Writer, 0, len(writers))
+	for _, w := range writers {
+		if mw, ok := w.(*multiWriter); ok {
+			all = append(all, mw.writers...)
+		} else {
+			all = append(all, w)
+		}
+	}
+	return &multiWriter{all}
 }
 
 func (w *multiWriter) WriteWarning(wrr Warning) error {
@@ -58,3 +58,32 @@ func TestNewMultiWriterErrors(t *testing.T) {
 		t.Fatalf("expected %v, got %v", writers[2].result, err)
 	}
 }
+
+func TestNewMultiWriter_Nested(t *testing.T) {
+	writers := []*mockWriter{{}, {}, {}}
+	w := warnings.NewMultiWriter(warnings.NewMultiWriter(writers[0], writers[1]), writers[2])
+	wantWrr := warnings.New("test")
+	if err := w.WriteWarning(wantWrr); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	for _, writer := range writers {
+		if len(writer.buf) != 1 || writer.buf[0] != wantWrr {
+			t.Fatalf("expected %v, got %v", wantWrr, writer.buf)
+		}
+	}
+}
+
+func TestWriterFunc(t *testing.T) {
+	var got warnings.Warning
+	w := warnings.WriterFunc(func(wrr warnings.Warning) error {
+		got = wrr
+		return nil
+	})
+	want := warnings.New("test")
+	if err := w.WriteWarning(want); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if got != want {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+}